@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
 	"workScheduler/internal/scheduler/server"
+	"workScheduler/internal/settings"
 )
 
 // TODO: Отслеживать сигналы, тушить все и на монге делать коннекшен клоус + кенсел контекста
@@ -17,8 +19,44 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	s := server.NewServer(ctx, "./config.yml")
-	s.Run()
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := server.Migrate(ctx, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 2 && os.Args[1] == "--check" {
+		if err := server.Check(ctx, os.Args[2], os.Args[3:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "lint" {
+		if err := server.LintConfig(os.Args[3:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if len(os.Args) > 2 && os.Args[1] == "settings" && os.Args[2] == "dump" {
+		if err := server.DumpSettings(os.Args[3:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	conf, err := settings.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	s, err := server.NewServer(ctx, conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := s.Run(); err != nil {
+		log.Fatal(err)
+	}
 	log.Println("Application started!")
 
 	c := make(chan os.Signal, 1)
@@ -1,19 +1,72 @@
 package actualizer
 
 import (
+	"container/heap"
 	"context"
 	"log"
+	"sync"
 	"time"
 	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// defaultHorizon is how far ahead transitions are loaded from repository.
+const defaultHorizon = 24 * time.Hour
+
+// defaultRescanInterval is how often works with transitions due soon are read from repository, it
+// bounds the delay of transitions of works written by other replicas.
+const defaultRescanInterval = time.Minute
+
+// Actualizer moves automatic works to in_progress at their start and to completed at their end,
+// manual works are started and finished by engineers and become overdue at their end. Upcoming
+// transitions are kept in a timer queue built from the schedule and from works written through
+// the repository returned by Watch, so repository is only read when a transition is due. Works
+// are queued only while the actualizer runs. Works written through other replicas are not seen
+// by Watch and storages have no change feed, so they are picked up by a periodic rescan, which
+// reads only works with transitions due before the next rescan.
 type Actualizer struct {
-	Repository repository.ReadWriteRepository
+	Repository     repository.ReadWriteRepository
+	Horizon        time.Duration
+	RescanInterval time.Duration
+
+	mu      sync.Mutex
+	running bool
+	queue   transitionQueue
+	// queued skips transitions which are already in the queue
+	queued map[transition]bool
+	wake   chan struct{}
+	now    func() time.Time
+}
+
+type transition struct {
+	At     time.Time
+	WorkId string
+	Id     primitive.ObjectID
+}
+
+type transitionQueue []transition
+
+func (q transitionQueue) Len() int           { return len(q) }
+func (q transitionQueue) Less(i, j int) bool { return q[i].At.Before(q[j].At) }
+func (q transitionQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *transitionQueue) Push(x any)        { *q = append(*q, x.(transition)) }
+func (q *transitionQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
 }
 
 func NewActualizer(repo repository.ReadWriteRepository) *Actualizer {
 	return &Actualizer{
-		Repository: repo,
+		Repository:     repo,
+		Horizon:        defaultHorizon,
+		RescanInterval: defaultRescanInterval,
+		queued:         make(map[transition]bool),
+		wake:           make(chan struct{}, 1),
+		now:            time.Now,
 	}
 }
 
@@ -22,37 +75,231 @@ func (a *Actualizer) Run(ctx context.Context) {
 }
 
 func (a *Actualizer) actualize(ctx context.Context) {
-	ticker := time.NewTicker(1 * time.Second)
+	a.setRunning(true)
+	defer a.setRunning(false)
+
+	a.refresh(ctx)
+	refreshTicker := time.NewTicker(a.RescanInterval)
+	defer refreshTicker.Stop()
 
 	for {
+		timer := time.NewTimer(a.nextDelay())
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return
-		case <-ticker.C:
-			location, err := time.LoadLocation("")
-			if err != nil {
-				log.Printf("WARNING: Find error while parsing start time for actualizer, %s\n", err)
-				continue
-			}
-			now := time.Now()
-			first_time := time.Date(1970, 1, 1, 0, 0, 0, 0, location)
-			works, err := a.Repository.List(ctx, first_time, now, []string{}, []string{"planned", "in_progress"})
-			if err != nil {
-				log.Printf("WARNING: Find error while getting works for actualizer from data, %s\n", err)
-				continue
-			}
-			for _, work := range works {
-				wStartUnix := work.StartDate.Unix()
-				wEndunix := work.EndTime().Unix()
-				nowUnix := now.Unix()
-				if wStartUnix < nowUnix && wEndunix > nowUnix {
-					work.Status = "in_progress"
-					a.Repository.Update(ctx, work)
-				} else if wStartUnix < nowUnix && wEndunix < nowUnix {
-					work.Status = "completed"
-					a.Repository.Update(ctx, work)
-				}
+		case <-a.wake:
+		case <-refreshTicker.C:
+			a.rescan(ctx)
+		case <-timer.C:
+			a.fireDue(ctx)
+		}
+		timer.Stop()
+	}
+}
+
+// expectedStatus returns status of planned or in progress work at the moment, outcomes reported
+// by executor (failed, rolled_back, aborted) and other final statuses are kept.
+func expectedStatus(work *models.WorkItem, now time.Time) models.Status {
+	if work.Status != models.StatusPlanned && work.Status != models.StatusInProgress {
+		return work.Status
+	}
+	if work.WorkType == "manual" {
+		if !now.Before(work.EndTime()) {
+			return models.StatusOverdue
+		}
+		return work.Status
+	}
+	if !now.Before(work.EndTime()) {
+		return models.StatusCompleted
+	}
+	if !now.Before(work.StartDate) {
+		return models.StatusInProgress
+	}
+	return work.Status
+}
+
+func (a *Actualizer) nextDelay() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.queue) == 0 {
+		return a.Horizon
+	}
+	delay := a.queue[0].At.Sub(a.now())
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// setRunning switches queueing of written works, the queue of a stopped actualizer (e.g. on a
+// replica which lost leadership) is dropped.
+func (a *Actualizer) setRunning(running bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.running = running
+	if !running {
+		a.clear()
+	}
+}
+
+// Track queues transitions of the work, it is called for every written work and ignored while
+// the actualizer is not running.
+func (a *Actualizer) Track(work *models.WorkItem) {
+	a.mu.Lock()
+	running := a.running
+	a.mu.Unlock()
+	if running {
+		a.push(work)
+	}
+}
+
+func (a *Actualizer) push(work *models.WorkItem) {
+	if work.Status != models.StatusPlanned && work.Status != models.StatusInProgress {
+		return
+	}
+	a.mu.Lock()
+	if work.Status == models.StatusPlanned {
+		a.enqueue(transition{At: work.StartDate, WorkId: work.WorkId, Id: work.Id})
+	}
+	a.enqueue(transition{At: work.EndTime(), WorkId: work.WorkId, Id: work.Id})
+	a.mu.Unlock()
+
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+// enqueue pushes transition unless it is queued already, a.mu must be held.
+func (a *Actualizer) enqueue(t transition) {
+	// same instants read from repository and written through Watch are equal only in UTC
+	t.At = t.At.UTC()
+	if a.queued[t] {
+		return
+	}
+	a.queued[t] = true
+	heap.Push(&a.queue, t)
+}
+
+// clear drops the queue, a.mu must be held.
+func (a *Actualizer) clear() {
+	a.queue = a.queue[:0]
+	a.queued = make(map[transition]bool)
+}
+
+// refresh rebuilds the queue from repository on start, works which missed their transitions while
+// the service was down are actualized here.
+func (a *Actualizer) refresh(ctx context.Context) {
+	now := a.now()
+	// works tracked while listing stay in the queue, duplicates are skipped on fire
+	a.mu.Lock()
+	a.clear()
+	a.mu.Unlock()
+	a.scan(ctx, time.Unix(0, 0), now.Add(a.Horizon), repository.ListModeStartsIn)
+}
+
+// rescan queues works with transitions due before the next rescan, the queue already has
+// transitions of works written through Watch and loaded before, so only works written through
+// other replicas are new here.
+func (a *Actualizer) rescan(ctx context.Context) {
+	now := a.now()
+	a.scan(ctx, now, now.Add(2*a.RescanInterval), repository.ListModeOverlaps)
+}
+
+func (a *Actualizer) scan(ctx context.Context, from time.Time, to time.Time, mode repository.ListMode) {
+	now := a.now()
+	works, err := a.Repository.List(ctx, from, to, []string{}, []string{string(models.StatusPlanned), string(models.StatusInProgress)}, mode)
+	if err != nil {
+		log.Printf("WARNING: Find error while getting works for actualizer from data, %s\n", err)
+		return
+	}
+	for _, work := range works {
+		a.actualizeWork(ctx, work, now)
+		a.push(work)
+	}
+}
+
+// fireDue actualizes works with due transitions, works are read again as they may be changed
+// after the transition was queued.
+func (a *Actualizer) fireDue(ctx context.Context) {
+	now := a.now()
+	var due []transition
+	a.mu.Lock()
+	for len(a.queue) > 0 && !a.queue[0].At.After(now) {
+		t := heap.Pop(&a.queue).(transition)
+		delete(a.queued, t)
+		due = append(due, t)
+	}
+	a.mu.Unlock()
+
+	for _, t := range due {
+		works, err := a.Repository.GetById(ctx, t.WorkId)
+		if repository.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			log.Printf("WARNING: Find error while getting work %s for actualizer from data, %s\n", t.WorkId, err)
+			continue
+		}
+		for _, work := range works {
+			if work.Id == t.Id {
+				a.actualizeWork(ctx, work, now)
 			}
 		}
 	}
 }
+
+// actualizeWork writes the work only if its status has changed.
+func (a *Actualizer) actualizeWork(ctx context.Context, work *models.WorkItem, now time.Time) {
+	status := expectedStatus(work, now)
+	if status == work.Status {
+		return
+	}
+	if err := work.ChangeStatus(status, models.ActorActualizer); err != nil {
+		log.Printf("WARNING: Find error while actualizing work %s, %s\n", work.WorkId, err)
+		return
+	}
+	// automatic works run by plan, catch up after downtime must not shift them to now
+	switch status {
+	case models.StatusInProgress:
+		work.ActualStart = work.StartDate
+	case models.StatusCompleted:
+		if work.ActualStart.IsZero() {
+			work.ActualStart = work.StartDate
+		}
+		work.ActualEnd = work.EndTime()
+	}
+	if _, err := a.Repository.Update(ctx, work); err != nil {
+		log.Printf("WARNING: Find error while updating work %s status to %s, %s\n", work.WorkId, status, err)
+	}
+}
+
+// Watch returns repository which reports written works to the actualizer.
+func (a *Actualizer) Watch(repo repository.ReadWriteRepository) repository.ReadWriteRepository {
+	return &watchedRepository{
+		ReadWriteRepository: repo,
+		actualizer:          a,
+	}
+}
+
+type watchedRepository struct {
+	repository.ReadWriteRepository
+	actualizer *Actualizer
+}
+
+func (w *watchedRepository) Add(ctx context.Context, work *models.WorkItem) (*models.WorkItem, error) {
+	result, err := w.ReadWriteRepository.Add(ctx, work)
+	if err == nil {
+		w.actualizer.Track(result)
+	}
+	return result, err
+}
+
+func (w *watchedRepository) Update(ctx context.Context, work *models.WorkItem) (*models.WorkItem, error) {
+	result, err := w.ReadWriteRepository.Update(ctx, work)
+	if err == nil {
+		w.actualizer.Track(result)
+	}
+	return result, err
+}
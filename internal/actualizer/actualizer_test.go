@@ -0,0 +1,152 @@
+package actualizer
+
+import (
+	"context"
+	"testing"
+	"time"
+	"workScheduler/internal/repository"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
+	"workScheduler/internal/scheduler/models"
+)
+
+// countingRepository counts updates written by actualizer
+type countingRepository struct {
+	repository.ReadWriteRepository
+	updates int
+}
+
+func (c *countingRepository) Update(ctx context.Context, work *models.WorkItem) (*models.WorkItem, error) {
+	c.updates++
+	return c.ReadWriteRepository.Update(ctx, work)
+}
+
+func TestActualize(t *testing.T) {
+
+	t.Run("succees catch up and fire transitions", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Date(2023, 04, 19, 10, 0, 0, 0, time.UTC)
+		repo := &countingRepository{ReadWriteRepository: inmemoryrepository.NewInmemoryRepository()}
+		a := NewActualizer(repo)
+		a.now = func() time.Time { return now }
+
+		works := []*models.WorkItem{
+			{WorkId: "missed", StartDate: now.Add(-2 * time.Hour), DurationMinutes: 60, Status: "planned"},
+			{WorkId: "started", StartDate: now.Add(-30 * time.Minute), DurationMinutes: 60, Status: "planned"},
+			{WorkId: "running", StartDate: now.Add(-30 * time.Minute), DurationMinutes: 60, Status: "in_progress"},
+			{WorkId: "canceled", StartDate: now.Add(-30 * time.Minute), DurationMinutes: 60, Status: "canceled"},
+			{WorkId: "failed", StartDate: now.Add(-30 * time.Minute), DurationMinutes: 60, Status: "failed", Reason: "timeout"},
+		}
+		for _, work := range works {
+			repo.ReadWriteRepository.Add(ctx, work)
+		}
+		watched := a.Watch(repo.ReadWriteRepository)
+		watched.Add(ctx, &models.WorkItem{WorkId: "future", StartDate: now.Add(time.Hour), DurationMinutes: 60, Status: "planned"})
+
+		a.refresh(ctx)
+		expected := map[string]string{"missed": "completed", "started": "in_progress", "running": "in_progress", "canceled": "canceled", "failed": "failed", "future": "planned"}
+		checkStatuses(t, repo, expected)
+		if repo.updates != 2 {
+			t.Errorf("unexpected updates count after catch up: %v, want 2", repo.updates)
+		}
+		if missed, _ := repo.GetById(ctx, "missed"); !missed[0].ActualStart.Equal(works[0].StartDate) || !missed[0].ActualEnd.Equal(works[0].EndTime()) {
+			t.Errorf("unexpected actual start and end of missed work: %v", missed[0])
+		}
+
+		a.fireDue(ctx)
+		if repo.updates != 2 {
+			t.Errorf("unexpected updates without due transitions: %v, want 2", repo.updates)
+		}
+
+		now = now.Add(90 * time.Minute)
+		if delay := a.nextDelay(); delay != 0 {
+			t.Errorf("unexpected delay of due transitions: %v", delay)
+		}
+		a.fireDue(ctx)
+		expected["started"], expected["running"], expected["future"] = "completed", "completed", "in_progress"
+		checkStatuses(t, repo, expected)
+		if repo.updates != 5 {
+			t.Errorf("unexpected updates count after transitions: %v, want 5", repo.updates)
+		}
+	})
+
+	t.Run("succees manual works wait for start and become overdue", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Date(2023, 04, 19, 10, 0, 0, 0, time.UTC)
+		repo := inmemoryrepository.NewInmemoryRepository()
+		a := NewActualizer(repo)
+		a.now = func() time.Time { return now }
+
+		works := []*models.WorkItem{
+			{WorkId: "not_started", StartDate: now.Add(-30 * time.Minute), DurationMinutes: 60, Status: "planned", WorkType: "manual"},
+			{WorkId: "missed", StartDate: now.Add(-2 * time.Hour), DurationMinutes: 60, Status: "planned", WorkType: "manual"},
+			{WorkId: "running", StartDate: now.Add(-30 * time.Minute), DurationMinutes: 60, Status: "in_progress", WorkType: "manual"},
+		}
+		for _, work := range works {
+			repo.Add(ctx, work)
+		}
+
+		a.refresh(ctx)
+		expected := map[string]string{"not_started": "planned", "missed": "overdue", "running": "in_progress"}
+		checkStatuses(t, repo, expected)
+
+		now = now.Add(time.Hour)
+		a.fireDue(ctx)
+		expected["not_started"], expected["running"] = "overdue", "overdue"
+		checkStatuses(t, repo, expected)
+	})
+}
+
+func checkStatuses(t *testing.T, repo repository.ReadRepository, expected map[string]string) {
+	for workId, status := range expected {
+		works, err := repo.GetById(context.Background(), workId)
+		if err != nil || len(works) != 1 || string(works[0].Status) != status {
+			t.Errorf("unexpected work %s: %v %v, want status %s", workId, works, err, status)
+		}
+	}
+}
+
+func TestActualizeOtherReplicaWorks(t *testing.T) {
+
+	t.Run("succees start work written through follower", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		repo := inmemoryrepository.NewInmemoryRepository()
+		leader := NewActualizer(repo)
+		leader.RescanInterval = 10 * time.Millisecond
+		leader.Run(ctx)
+		follower := NewActualizer(repo)
+
+		work := &models.WorkItem{WorkId: "follower", StartDate: time.Now().Add(50 * time.Millisecond), DurationMinutes: 60, Status: "planned"}
+		if _, err := follower.Watch(repo).Add(ctx, work); err != nil {
+			t.Fatal(err)
+		}
+		if follower.nextDelay() != follower.Horizon {
+			t.Errorf("follower queued works while not running")
+		}
+
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			if works, _ := repo.GetById(ctx, "follower"); len(works) == 1 && works[0].Status == models.StatusInProgress {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Errorf("work written through follower was not started by leader")
+	})
+	t.Run("succees rescan only works due before next rescan", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Date(2023, 04, 19, 10, 0, 0, 0, time.UTC)
+		repo := inmemoryrepository.NewInmemoryRepository()
+		a := NewActualizer(repo)
+		a.now = func() time.Time { return now }
+
+		repo.Add(ctx, &models.WorkItem{WorkId: "soon", StartDate: now.Add(30 * time.Second), DurationMinutes: 60, Status: "planned"})
+		repo.Add(ctx, &models.WorkItem{WorkId: "later", StartDate: now.Add(time.Hour), DurationMinutes: 60, Status: "planned"})
+
+		a.rescan(ctx)
+		a.rescan(ctx)
+		if len(a.queue) != 2 || a.queue[0].WorkId != "soon" {
+			t.Errorf("unexpected queue after rescans: %v", a.queue)
+		}
+	})
+}
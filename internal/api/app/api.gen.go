@@ -1,6 +1,6 @@
 // Package api provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.12.2 DO NOT EDIT.
+// Code generated by github.com/deepmap/oapi-codegen version v1.12.4 DO NOT EDIT.
 package api
 
 import (
@@ -19,6 +19,20 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// Defines values for ConfigImpactViolationsRule.
+const (
+	BlackList              ConfigImpactViolationsRule = "black_list"
+	MaxWorkDurationMinutes ConfigImpactViolationsRule = "max_work_duration_minutes"
+	MinAvialableZones      ConfigImpactViolationsRule = "min_avialable_zones"
+	WhiteList              ConfigImpactViolationsRule = "white_list"
+)
+
+// Defines values for ErrorIssuesSeverity.
+const (
+	ErrorIssuesSeverityError   ErrorIssuesSeverity = "error"
+	ErrorIssuesSeverityWarning ErrorIssuesSeverity = "warning"
+)
+
 // Defines values for PostWorkPriority.
 const (
 	PostWorkPriorityCritical PostWorkPriority = "critical"
@@ -31,6 +45,14 @@ const (
 	PostWorkWorkTypeManual    PostWorkWorkType = "manual"
 )
 
+// Defines values for WorkOutcomeStatus.
+const (
+	WorkOutcomeStatusAborted    WorkOutcomeStatus = "aborted"
+	WorkOutcomeStatusCompleted  WorkOutcomeStatus = "completed"
+	WorkOutcomeStatusFailed     WorkOutcomeStatus = "failed"
+	WorkOutcomeStatusRolledBack WorkOutcomeStatus = "rolled_back"
+)
+
 // Defines values for WorksPriority.
 const (
 	WorksPriorityCritical WorksPriority = "critical"
@@ -39,9 +61,15 @@ const (
 
 // Defines values for WorksStatus.
 const (
+	WorksStatusAborted    WorksStatus = "aborted"
 	WorksStatusCanceled   WorksStatus = "canceled"
+	WorksStatusCompleted  WorksStatus = "completed"
+	WorksStatusFailed     WorksStatus = "failed"
+	WorksStatusHeld       WorksStatus = "held"
 	WorksStatusInProgress WorksStatus = "in_progress"
+	WorksStatusOverdue    WorksStatus = "overdue"
 	WorksStatusPlanned    WorksStatus = "planned"
+	WorksStatusRolledBack WorksStatus = "rolled_back"
 )
 
 // Defines values for WorksWorkType.
@@ -50,13 +78,110 @@ const (
 	WorksWorkTypeManual    WorksWorkType = "manual"
 )
 
+// Defines values for GetArchiveParamsStatuses.
+const (
+	GetArchiveParamsStatusesAborted    GetArchiveParamsStatuses = "aborted"
+	GetArchiveParamsStatusesCanceled   GetArchiveParamsStatuses = "canceled"
+	GetArchiveParamsStatusesCompleted  GetArchiveParamsStatuses = "completed"
+	GetArchiveParamsStatusesFailed     GetArchiveParamsStatuses = "failed"
+	GetArchiveParamsStatusesRolledBack GetArchiveParamsStatuses = "rolled_back"
+)
+
+// Defines values for GetReportParamsGroupBy.
+const (
+	GetReportParamsGroupByTeam     GetReportParamsGroupBy = "team"
+	GetReportParamsGroupByWorkType GetReportParamsGroupBy = "workType"
+	GetReportParamsGroupByZone     GetReportParamsGroupBy = "zone"
+)
+
+// Defines values for GetscheduleParamsMode.
+const (
+	EndsIn   GetscheduleParamsMode = "ends_in"
+	Overlaps GetscheduleParamsMode = "overlaps"
+	StartsIn GetscheduleParamsMode = "starts_in"
+)
+
+// Defines values for GetscheduleParamsSortBy.
+const (
+	Deadline  GetscheduleParamsSortBy = "deadline"
+	EndDate   GetscheduleParamsSortBy = "endDate"
+	StartDate GetscheduleParamsSortBy = "startDate"
+)
+
+// Defines values for GetscheduleParamsSortOrder.
+const (
+	Asc  GetscheduleParamsSortOrder = "asc"
+	Desc GetscheduleParamsSortOrder = "desc"
+)
+
+// Defines values for GetscheduleParamsFields.
+const (
+	GetscheduleParamsFieldsDeadline        GetscheduleParamsFields = "deadline"
+	GetscheduleParamsFieldsDurationMinutes GetscheduleParamsFields = "durationMinutes"
+	GetscheduleParamsFieldsPriority        GetscheduleParamsFields = "priority"
+	GetscheduleParamsFieldsReason          GetscheduleParamsFields = "reason"
+	GetscheduleParamsFieldsStartDate       GetscheduleParamsFields = "startDate"
+	GetscheduleParamsFieldsStatus          GetscheduleParamsFields = "status"
+	GetscheduleParamsFieldsTeam            GetscheduleParamsFields = "team"
+	GetscheduleParamsFieldsWorkId          GetscheduleParamsFields = "workId"
+	GetscheduleParamsFieldsWorkType        GetscheduleParamsFields = "workType"
+	GetscheduleParamsFieldsZones           GetscheduleParamsFields = "zones"
+)
+
 // Defines values for GetscheduleParamsStatuses.
 const (
+	GetscheduleParamsStatusesAborted    GetscheduleParamsStatuses = "aborted"
 	GetscheduleParamsStatusesCanceled   GetscheduleParamsStatuses = "canceled"
+	GetscheduleParamsStatusesCompleted  GetscheduleParamsStatuses = "completed"
+	GetscheduleParamsStatusesFailed     GetscheduleParamsStatuses = "failed"
+	GetscheduleParamsStatusesHeld       GetscheduleParamsStatuses = "held"
 	GetscheduleParamsStatusesInProgress GetscheduleParamsStatuses = "in_progress"
+	GetscheduleParamsStatusesOverdue    GetscheduleParamsStatuses = "overdue"
 	GetscheduleParamsStatusesPlanned    GetscheduleParamsStatuses = "planned"
+	GetscheduleParamsStatusesRolledBack GetscheduleParamsStatuses = "rolled_back"
 )
 
+// ConfigDocument defines model for configDocument.
+type ConfigDocument struct {
+	// Document Config in YAML as config file
+	Document *string `json:"document,omitempty"`
+
+	// Revision Revision in config history
+	Revision *int64 `json:"revision,omitempty"`
+
+	// Version Version of config applied by this replica
+	Version *int64 `json:"version,omitempty"`
+}
+
+// ConfigHistory defines model for configHistory.
+type ConfigHistory = []struct {
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	Document  *string    `json:"document,omitempty"`
+	Revision  *int64     `json:"revision,omitempty"`
+}
+
+// ConfigImpact defines model for configImpact.
+type ConfigImpact struct {
+	CheckedAt     *time.Time `json:"checkedAt,omitempty"`
+	ConfigVersion *int64     `json:"configVersion,omitempty"`
+	Proposals     *[]struct {
+		// Error Reason why work can't be rescheduled under the config
+		Error           *string `json:"error,omitempty"`
+		Schedule        *Works  `json:"schedule,omitempty"`
+		UserMustApprove *bool   `json:"userMustApprove,omitempty"`
+		WorkId          *string `json:"workId,omitempty"`
+	} `json:"proposals,omitempty"`
+	Violations *[]struct {
+		Message *string                     `json:"message,omitempty"`
+		Rule    *ConfigImpactViolationsRule `json:"rule,omitempty"`
+		WorkId  *string                     `json:"workId,omitempty"`
+		Zone    *string                     `json:"zone,omitempty"`
+	} `json:"violations,omitempty"`
+}
+
+// ConfigImpactViolationsRule defines model for ConfigImpact.Violations.Rule.
+type ConfigImpactViolationsRule string
+
 // Error defines model for error.
 type Error struct {
 	Alternative *[]struct {
@@ -66,17 +191,37 @@ type Error struct {
 		Zones           *[]string  `json:"zones,omitempty"`
 	} `json:"alternative,omitempty"`
 	ErrorCode *string `json:"errorCode,omitempty"`
-	Message   *string `json:"message,omitempty"`
+
+	// Issues Problems found in invalid config
+	Issues *[]struct {
+		// Line Line in config document, absent if the value is not in document
+		Line    *int    `json:"line,omitempty"`
+		Message *string `json:"message,omitempty"`
+
+		// Path YAML path of the value, e.g. white_list.Zone_1[0].end_hour
+		Path     *string              `json:"path,omitempty"`
+		Severity *ErrorIssuesSeverity `json:"severity,omitempty"`
+	} `json:"issues,omitempty"`
+	Message *string `json:"message,omitempty"`
 }
 
+// ErrorIssuesSeverity defines model for Error.Issues.Severity.
+type ErrorIssuesSeverity string
+
 // PostWork defines model for postWork.
 type PostWork struct {
-	Deadline        *time.Time        `json:"deadline,omitempty"`
-	DurationMinutes *int32            `json:"durationMinutes,omitempty"`
-	Priority        *PostWorkPriority `json:"priority,omitempty"`
-	StartDate       *time.Time        `json:"startDate,omitempty"`
-	WorkType        *PostWorkWorkType `json:"workType,omitempty"`
-	Zones           *interface{}      `json:"zones,omitempty"`
+	Deadline        *time.Time `json:"deadline,omitempty"`
+	DurationMinutes *int32     `json:"durationMinutes,omitempty"`
+
+	// HoldUntil Create work as held slot until this time, it must be confirmed before or it is released
+	HoldUntil *time.Time        `json:"holdUntil,omitempty"`
+	Priority  *PostWorkPriority `json:"priority,omitempty"`
+	StartDate *time.Time        `json:"startDate,omitempty"`
+
+	// Team Team responsible for work
+	Team     *string           `json:"team,omitempty"`
+	WorkType *PostWorkWorkType `json:"workType,omitempty"`
+	Zones    *interface{}      `json:"zones,omitempty"`
 }
 
 // PostWorkPriority defines model for PostWork.Priority.
@@ -90,17 +235,75 @@ type ProlongateWork struct {
 	DurationMinutes *int32 `json:"durationMinutes,omitempty"`
 }
 
+// PutConfig defines model for putConfig.
+type PutConfig struct {
+	// Document Config in YAML as config file
+	Document string `json:"document"`
+}
+
+// PutZone defines model for putZone.
+type PutZone struct {
+	BlackListed  *bool  `json:"blackListed,omitempty"`
+	PauseMinutes *int32 `json:"pauseMinutes,omitempty"`
+
+	// Windows White-list windows, window with end hour not greater than start hour ends next day
+	Windows *[]Window `json:"windows,omitempty"`
+}
+
+// Report defines model for report.
+type Report = []struct {
+	ActualMinutes       *int32  `json:"actualMinutes,omitempty"`
+	DeviationMinutes    *int32  `json:"deviationMinutes,omitempty"`
+	Key                 *string `json:"key,omitempty"`
+	PlannedMinutes      *int32  `json:"plannedMinutes,omitempty"`
+	ProlongationMinutes *int32  `json:"prolongationMinutes,omitempty"`
+	Prolongations       *int32  `json:"prolongations,omitempty"`
+	Works               *int32  `json:"works,omitempty"`
+}
+
+// Window defines model for window.
+type Window struct {
+	// EffectiveFrom Window is in effect from this date (2006-01-02) or RFC3339 time, inclusive
+	EffectiveFrom *string `json:"effectiveFrom,omitempty"`
+
+	// EffectiveUntil Window is in effect until this date (2006-01-02) or RFC3339 time, exclusive
+	EffectiveUntil *string `json:"effectiveUntil,omitempty"`
+	EndHour        *int32  `json:"endHour,omitempty"`
+	StartHour      *int32  `json:"startHour,omitempty"`
+}
+
+// WorkOutcome defines model for workOutcome.
+type WorkOutcome struct {
+	Reason *string           `json:"reason,omitempty"`
+	Status WorkOutcomeStatus `json:"status"`
+}
+
+// WorkOutcomeStatus defines model for WorkOutcome.Status.
+type WorkOutcomeStatus string
+
 // Works defines model for works.
 type Works = []struct {
-	Deadline        *time.Time     `json:"deadline,omitempty"`
-	DurationMinutes *int32         `json:"durationMinutes,omitempty"`
-	Id              *string        `json:"id,omitempty"`
-	Priority        *WorksPriority `json:"priority,omitempty"`
-	StartDate       *time.Time     `json:"startDate,omitempty"`
-	Status          *WorksStatus   `json:"status,omitempty"`
-	WorkId          *string        `json:"workId,omitempty"`
-	WorkType        *WorksWorkType `json:"workType,omitempty"`
-	Zones           *[]string      `json:"zones,omitempty"`
+	ActualEnd   *time.Time `json:"actualEnd,omitempty"`
+	ActualStart *time.Time `json:"actualStart,omitempty"`
+
+	// ConfigVersion Version of configuration used by scheduler for the last decision about work
+	ConfigVersion       *int64         `json:"configVersion,omitempty"`
+	Deadline            *time.Time     `json:"deadline,omitempty"`
+	DurationMinutes     *int32         `json:"durationMinutes,omitempty"`
+	HoldUntil           *time.Time     `json:"holdUntil,omitempty"`
+	Id                  *string        `json:"id,omitempty"`
+	Priority            *WorksPriority `json:"priority,omitempty"`
+	ProlongationCount   *int32         `json:"prolongationCount,omitempty"`
+	ProlongationMinutes *int32         `json:"prolongationMinutes,omitempty"`
+
+	// Reason Reason of failed, rolled back or aborted work
+	Reason    *string        `json:"reason,omitempty"`
+	StartDate *time.Time     `json:"startDate,omitempty"`
+	Status    *WorksStatus   `json:"status,omitempty"`
+	Team      *string        `json:"team,omitempty"`
+	WorkId    *string        `json:"workId,omitempty"`
+	WorkType  *WorksWorkType `json:"workType,omitempty"`
+	Zones     *[]string      `json:"zones,omitempty"`
 }
 
 // WorksPriority defines model for Works.Priority.
@@ -112,14 +315,87 @@ type WorksStatus string
 // WorksWorkType defines model for Works.WorkType.
 type WorksWorkType string
 
+// Zone defines model for zone.
+type Zone struct {
+	BlackListed    *bool `json:"blackListed,omitempty"`
+	Decommissioned *bool `json:"decommissioned,omitempty"`
+
+	// Managed Zone is managed through API
+	Managed      *bool     `json:"managed,omitempty"`
+	PauseMinutes *int32    `json:"pauseMinutes,omitempty"`
+	Windows      *[]Window `json:"windows,omitempty"`
+	ZoneId       *string   `json:"zoneId,omitempty"`
+}
+
+// GetArchiveParams defines parameters for GetArchive.
+type GetArchiveParams struct {
+	// FromDate Range starts from
+	FromDate time.Time `form:"fromDate" json:"fromDate"`
+
+	// ToDate Range ends to
+	ToDate time.Time `form:"toDate" json:"toDate"`
+
+	// Zones List of zones
+	Zones *[]string `form:"zones,omitempty" json:"zones,omitempty"`
+
+	// Statuses Statuses of work to get
+	Statuses *[]GetArchiveParamsStatuses `form:"statuses,omitempty" json:"statuses,omitempty"`
+}
+
+// GetArchiveParamsStatuses defines parameters for GetArchive.
+type GetArchiveParamsStatuses string
+
+// GetConfigImpactParams defines parameters for GetConfigImpact.
+type GetConfigImpactParams struct {
+	// Propose Generate reschedule proposals for violating works
+	Propose *bool `form:"propose,omitempty" json:"propose,omitempty"`
+}
+
+// GetReportParams defines parameters for GetReport.
+type GetReportParams struct {
+	// FromDate Range starts from
+	FromDate time.Time `form:"fromDate" json:"fromDate"`
+
+	// ToDate Range ends to
+	ToDate time.Time `form:"toDate" json:"toDate"`
+
+	// GroupBy How works are grouped
+	GroupBy *GetReportParamsGroupBy `form:"groupBy,omitempty" json:"groupBy,omitempty"`
+
+	// Zones List of zones
+	Zones *[]string `form:"zones,omitempty" json:"zones,omitempty"`
+}
+
+// GetReportParamsGroupBy defines parameters for GetReport.
+type GetReportParamsGroupBy string
+
 // GetscheduleParams defines parameters for Getschedule.
 type GetscheduleParams struct {
-	// FromDate Starts from
+	// FromDate Range starts from
 	FromDate *time.Time `form:"fromDate,omitempty" json:"fromDate,omitempty"`
 
-	// ToDate Starts to
+	// ToDate Range ends to
 	ToDate *time.Time `form:"toDate,omitempty" json:"toDate,omitempty"`
 
+	// Mode How works are matched against the range: overlaps - work is in progress at any moment of the range (default),
+	// starts_in - work starts inside the range, ends_in - work ends inside the range
+	Mode *GetscheduleParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Limit Max works count in response
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from X-Next-Cursor header of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// SortBy Field to sort works by
+	SortBy *GetscheduleParamsSortBy `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// SortOrder Sort order
+	SortOrder *GetscheduleParamsSortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
+
+	// Fields Work fields to return, all fields if empty
+	Fields *[]GetscheduleParamsFields `form:"fields,omitempty" json:"fields,omitempty"`
+
 	// Zones List of zones
 	Zones *[]string `form:"zones,omitempty" json:"zones,omitempty"`
 
@@ -127,20 +403,62 @@ type GetscheduleParams struct {
 	Statuses *[]GetscheduleParamsStatuses `form:"statuses,omitempty" json:"statuses,omitempty"`
 }
 
+// GetscheduleParamsMode defines parameters for Getschedule.
+type GetscheduleParamsMode string
+
+// GetscheduleParamsSortBy defines parameters for Getschedule.
+type GetscheduleParamsSortBy string
+
+// GetscheduleParamsSortOrder defines parameters for Getschedule.
+type GetscheduleParamsSortOrder string
+
+// GetscheduleParamsFields defines parameters for Getschedule.
+type GetscheduleParamsFields string
+
 // GetscheduleParamsStatuses defines parameters for Getschedule.
 type GetscheduleParamsStatuses string
 
+// PutConfigJSONRequestBody defines body for PutConfig for application/json ContentType.
+type PutConfigJSONRequestBody = PutConfig
+
 // AddWorkJSONRequestBody defines body for AddWork for application/json ContentType.
 type AddWorkJSONRequestBody = PostWork
 
 // MoveWorkByIdJSONRequestBody defines body for MoveWorkById for application/json ContentType.
 type MoveWorkByIdJSONRequestBody = Works
 
+// ReportWorkOutcomeByIdJSONRequestBody defines body for ReportWorkOutcomeById for application/json ContentType.
+type ReportWorkOutcomeByIdJSONRequestBody = WorkOutcome
+
 // ProlongateWorkByIdJSONRequestBody defines body for ProlongateWorkById for application/json ContentType.
 type ProlongateWorkByIdJSONRequestBody = ProlongateWork
 
+// PutZoneJSONRequestBody defines body for PutZone for application/json ContentType.
+type PutZoneJSONRequestBody = PutZone
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get archived works
+	// (GET /archive)
+	GetArchive(w http.ResponseWriter, r *http.Request, params GetArchiveParams)
+	// Get the current config
+	// (GET /config)
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	// Replace the config
+	// (PUT /config)
+	PutConfig(w http.ResponseWriter, r *http.Request)
+	// Get config history
+	// (GET /config/history)
+	GetConfigHistory(w http.ResponseWriter, r *http.Request)
+	// Roll back config to the revision
+	// (POST /config/history/{revision}/rollback)
+	RollbackConfig(w http.ResponseWriter, r *http.Request, revision int64)
+	// Check planned works against the current config
+	// (GET /config/impact)
+	GetConfigImpact(w http.ResponseWriter, r *http.Request, params GetConfigImpactParams)
+	// Compare planned and actual durations of works
+	// (GET /report)
+	GetReport(w http.ResponseWriter, r *http.Request, params GetReportParams)
 	// Get schedule with works
 	// (GET /schedule)
 	Getschedule(w http.ResponseWriter, r *http.Request, params GetscheduleParams)
@@ -153,12 +471,36 @@ type ServerInterface interface {
 	// Cancel planned work by id
 	// (PUT /work/{workId}/cancel)
 	CancelWorkById(w http.ResponseWriter, r *http.Request, workId string)
+	// Confirm held work
+	// (POST /work/{workId}/confirm)
+	ConfirmWorkById(w http.ResponseWriter, r *http.Request, workId string)
+	// Confirm finish of manual work
+	// (POST /work/{workId}/finish)
+	FinishWorkById(w http.ResponseWriter, r *http.Request, workId string)
 	// Move start time and duration for planned work
 	// (PUT /work/{workId}/move)
 	MoveWorkById(w http.ResponseWriter, r *http.Request, workId string)
+	// Report outcome of work
+	// (POST /work/{workId}/outcome)
+	ReportWorkOutcomeById(w http.ResponseWriter, r *http.Request, workId string)
 	// Prolongate work duration started work
 	// (PUT /work/{workId}/prolongate)
 	ProlongateWorkById(w http.ResponseWriter, r *http.Request, workId string)
+	// Confirm start of manual work
+	// (POST /work/{workId}/start)
+	StartWorkById(w http.ResponseWriter, r *http.Request, workId string)
+	// Get zones of the effective config
+	// (GET /zones)
+	GetZones(w http.ResponseWriter, r *http.Request)
+	// Decommission zone
+	// (DELETE /zones/{zoneId})
+	DecommissionZone(w http.ResponseWriter, r *http.Request, zoneId string)
+	// Get zone
+	// (GET /zones/{zoneId})
+	GetZone(w http.ResponseWriter, r *http.Request, zoneId string)
+	// Add or change zone
+	// (PUT /zones/{zoneId})
+	PutZone(w http.ResponseWriter, r *http.Request, zoneId string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -170,6 +512,237 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
 
+// GetArchive operation middleware
+func (siw *ServerInterfaceWrapper) GetArchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetArchiveParams
+
+	// ------------- Required query parameter "fromDate" -------------
+
+	if paramValue := r.URL.Query().Get("fromDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "fromDate"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "fromDate", r.URL.Query(), &params.FromDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fromDate", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "toDate" -------------
+
+	if paramValue := r.URL.Query().Get("toDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "toDate"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "toDate", r.URL.Query(), &params.ToDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "toDate", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "zones" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "zones", r.URL.Query(), &params.Zones)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "zones", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "statuses" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "statuses", r.URL.Query(), &params.Statuses)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "statuses", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetArchive(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetConfig operation middleware
+func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConfig(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutConfig operation middleware
+func (siw *ServerInterfaceWrapper) PutConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutConfig(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetConfigHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetConfigHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConfigHistory(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// RollbackConfig operation middleware
+func (siw *ServerInterfaceWrapper) RollbackConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "revision" -------------
+	var revision int64
+
+	err = runtime.BindStyledParameter("simple", false, "revision", mux.Vars(r)["revision"], &revision)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revision", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RollbackConfig(w, r, revision)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetConfigImpact operation middleware
+func (siw *ServerInterfaceWrapper) GetConfigImpact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetConfigImpactParams
+
+	// ------------- Optional query parameter "propose" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "propose", r.URL.Query(), &params.Propose)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "propose", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConfigImpact(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetReport operation middleware
+func (siw *ServerInterfaceWrapper) GetReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReportParams
+
+	// ------------- Required query parameter "fromDate" -------------
+
+	if paramValue := r.URL.Query().Get("fromDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "fromDate"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "fromDate", r.URL.Query(), &params.FromDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fromDate", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "toDate" -------------
+
+	if paramValue := r.URL.Query().Get("toDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "toDate"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "toDate", r.URL.Query(), &params.ToDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "toDate", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "groupBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupBy", r.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "zones" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "zones", r.URL.Query(), &params.Zones)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "zones", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReport(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // Getschedule operation middleware
 func (siw *ServerInterfaceWrapper) Getschedule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -195,6 +768,54 @@ func (siw *ServerInterfaceWrapper) Getschedule(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", r.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortOrder" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortOrder", r.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortOrder", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "zones" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "zones", r.URL.Query(), &params.Zones)
@@ -289,6 +910,58 @@ func (siw *ServerInterfaceWrapper) CancelWorkById(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
+// ConfirmWorkById operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmWorkById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "workId" -------------
+	var workId string
+
+	err = runtime.BindStyledParameter("simple", false, "workId", mux.Vars(r)["workId"], &workId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ConfirmWorkById(w, r, workId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// FinishWorkById operation middleware
+func (siw *ServerInterfaceWrapper) FinishWorkById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "workId" -------------
+	var workId string
+
+	err = runtime.BindStyledParameter("simple", false, "workId", mux.Vars(r)["workId"], &workId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FinishWorkById(w, r, workId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // MoveWorkById operation middleware
 func (siw *ServerInterfaceWrapper) MoveWorkById(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -315,6 +988,32 @@ func (siw *ServerInterfaceWrapper) MoveWorkById(w http.ResponseWriter, r *http.R
 	handler(w, r.WithContext(ctx))
 }
 
+// ReportWorkOutcomeById operation middleware
+func (siw *ServerInterfaceWrapper) ReportWorkOutcomeById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "workId" -------------
+	var workId string
+
+	err = runtime.BindStyledParameter("simple", false, "workId", mux.Vars(r)["workId"], &workId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReportWorkOutcomeById(w, r, workId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // ProlongateWorkById operation middleware
 func (siw *ServerInterfaceWrapper) ProlongateWorkById(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -341,6 +1040,125 @@ func (siw *ServerInterfaceWrapper) ProlongateWorkById(w http.ResponseWriter, r *
 	handler(w, r.WithContext(ctx))
 }
 
+// StartWorkById operation middleware
+func (siw *ServerInterfaceWrapper) StartWorkById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "workId" -------------
+	var workId string
+
+	err = runtime.BindStyledParameter("simple", false, "workId", mux.Vars(r)["workId"], &workId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartWorkById(w, r, workId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetZones operation middleware
+func (siw *ServerInterfaceWrapper) GetZones(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetZones(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DecommissionZone operation middleware
+func (siw *ServerInterfaceWrapper) DecommissionZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "zoneId" -------------
+	var zoneId string
+
+	err = runtime.BindStyledParameter("simple", false, "zoneId", mux.Vars(r)["zoneId"], &zoneId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "zoneId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DecommissionZone(w, r, zoneId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetZone operation middleware
+func (siw *ServerInterfaceWrapper) GetZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "zoneId" -------------
+	var zoneId string
+
+	err = runtime.BindStyledParameter("simple", false, "zoneId", mux.Vars(r)["zoneId"], &zoneId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "zoneId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetZone(w, r, zoneId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutZone operation middleware
+func (siw *ServerInterfaceWrapper) PutZone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "zoneId" -------------
+	var zoneId string
+
+	err = runtime.BindStyledParameter("simple", false, "zoneId", mux.Vars(r)["zoneId"], &zoneId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "zoneId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutZone(w, r, zoneId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -454,6 +1272,20 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.HandleFunc(options.BaseURL+"/archive", wrapper.GetArchive).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/config", wrapper.GetConfig).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/config", wrapper.PutConfig).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/config/history", wrapper.GetConfigHistory).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/config/history/{revision}/rollback", wrapper.RollbackConfig).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/config/impact", wrapper.GetConfigImpact).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/report", wrapper.GetReport).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/schedule", wrapper.Getschedule).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/work", wrapper.AddWork).Methods("POST")
@@ -462,34 +1294,94 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/work/{workId}/cancel", wrapper.CancelWorkById).Methods("PUT")
 
+	r.HandleFunc(options.BaseURL+"/work/{workId}/confirm", wrapper.ConfirmWorkById).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/work/{workId}/finish", wrapper.FinishWorkById).Methods("POST")
+
 	r.HandleFunc(options.BaseURL+"/work/{workId}/move", wrapper.MoveWorkById).Methods("PUT")
 
+	r.HandleFunc(options.BaseURL+"/work/{workId}/outcome", wrapper.ReportWorkOutcomeById).Methods("POST")
+
 	r.HandleFunc(options.BaseURL+"/work/{workId}/prolongate", wrapper.ProlongateWorkById).Methods("PUT")
 
+	r.HandleFunc(options.BaseURL+"/work/{workId}/start", wrapper.StartWorkById).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/zones", wrapper.GetZones).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/zones/{zoneId}", wrapper.DecommissionZone).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/zones/{zoneId}", wrapper.GetZone).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/zones/{zoneId}", wrapper.PutZone).Methods("PUT")
+
 	return r
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xY32/bNhD+V4jbgL2osuN0L3prsmAw0A7FEmAPQTAw4tlmQ5Hs8WTXC/y/D6RsObWV",
-	"xG5+rFnzZFm6453uvu/jiddQuso7i5YDFNcQyglWMl0ikaN44cl5JNaYbkvDSFaynmL8qxmrsG2lUCqj",
-	"bTIZOaokQwFKMr5hXSFkwHOPUEBg0nYMiwxUTZK1sx+0rblZpHXUlg8HaydtGcdI0SuwJP5N8h6B/nG2",
-	"Wb5NfctkeUMSyTks1jfc5Scsedsia4p17BR2rldhCHLc9axrbe8C/+Xo6omqil9k5Q1CMch2qbAn7Ujz",
-	"PLnauoLiHAjHtZEEGZSkWZfSwEVH8G9ozszR1Vm6uY5WSVtLAxnIml0lWZed0dq+ti94Dqo8gAxUOYge",
-	"Dbb36nxne8gZZ8eS8ZYmPbDkXTFjWcJT021PYGjVWcHnxUtgyXW4GcsbaS2qGEvaEk261PZvT25MGEJn",
-	"5FjfYff7PAYiH09poom2I9c0PZSkfewiFHA20UEEpCmSUDhF4zwqMXIkTv88EUyyvBLaiuHZL0GcaXvl",
-	"RiNx6kwd3cVx7XPIwOgSbUivamUVw77zspygGOR9yKAmAwVMmH3R681ms1ymp7mjcW/pGnrvh8cnf5ye",
-	"vBnk/XzClUnvoDniCiJhRKShqg1GOEyRQpP+Qd7PDw6isfNopddQwGHezw8hAy95ksrXW/nGP2Pk7Sr8",
-	"jtwGEDPNE9FQJy3bID72Odq1a8UAJCtkpADF+eaSpxGVQYzIVZABfvEmyTxTjbEXUMDnGmkO2apm0TKh",
-	"OAPCz7UkVCvztQTtAu9Fdksu7HbLhN0T5fFeBxZuJBqE75TLyvQbVLirDFwHDDGF2F/BTkQ47JRIWDp3",
-	"5/IgGdnI+yIWPngXeRHXHvT78ad0ltEm8ErvjS4TLHufQny36xtJ/Uw4ggJ+6q1ntN5yQOs1sE56sFGb",
-	"uiwxjGqTmPe2/3abJImGiRxaCevEyNVWRetfHzHBZnzsSHBo0wBpVmK1Mswg1FUlaX4njVmOI0mhZe9F",
-	"9EzlSNuiCx2qcEwoGYXFmVj2tYGNtkJOtTTy0mDC8pZOvFMq7fINhTDwkVPzR6tRO+l1lGmfnGNuuqX3",
-	"4nuA3TMA6UgqsezKdwXefTq3gnPC7xrKvetmIlncudF9tfrlXGjVtc9FgB3Nh+q+fW6oVnIKS+GM++5a",
-	"N5cz0ibabqro5r7xKoAPEcDO9t4HmF6zXSUtrLukMD3eBTqN5Y+OnufXsReK2LuAdS9oK9ecJnVC9oOb",
-	"okjfhiLOp0JaJVafr+kD52bMLRhH7/8axI8/OdyK372KlYuNYwChg3BpKWny18HiRRNyX9rcS9L1wdet",
-	"VP3YmjQC0MZLedxG0Y9fnaj9v4i6cVrY0ctda/bKxZfLxV17vMnBxeLfAAAA///TqC3uoRkAAA==",
+	"H4sIAAAAAAAC/+xcbW8bt5P/KgTvgH8LrCXZyRWo3zlO0hhI2iBJm7vEhkEvRxKbXXJLciUrgb/7YUju",
+	"aleipJUdO2qid7aWD8PhzG+GM0N+oanKCyVBWkOPv1CTjiFn7s9UyaEYPVVpmYO0+EuhVQHaCnDfeeML",
+	"B5NqUVihJD2mp64nEZL838mrl4QZ4sciQ5EBTaidFUCPqbFayBG9SaiGiTCu7+JQb8IXHCwMMhbGKj2j",
+	"CR0qnTNLj6mQ9pfH84GFtDACjSNPQMcH/st/IGpYjcuKIhPAydWM2LEwREORiZR1meem/kld/Q2pxZn9",
+	"qC8CscdfqLCQm2U2phqYBX7i+FjPxJmFAyvyKLuanF/Ly1sRHn5gWrPZfCFnecHSiBSkY0g/bUe+H/Cv",
+	"+c502EecVBmWmTWcBK2VjokQM0qS6XhGpkp/IimT/7HkCogGlHZeZsBJKTloYscQpCFGd9Uap/hvDUN6",
+	"TP+rP1efftCdPs5isENpQL8qjT0pCq0m0NiuK6UyYBIbYeszHtnKLnszESpjuM51fMnBGDaCuLSE9YAs",
+	"c3r8kV5lLP10mQljaUKnY2Gh+idn15dI6yUvtZvyMheytGDwm5CXbCJYxq4yuPysJBh6EeHgyrUmFDvd",
+	"igmxFrUktBnBMgtaMismsIZdHBjPhISWZK7Xx8CRV4EhCyL96Cgq0sYybZ8yu8VEnrNN0peabGbOogw5",
+	"Zp0qHhcQYUwJZlmrXmt1lUFuyFCVkiM8CzlhmeBzBVrB34q37fFeCgkNkK8gLiHsyoC0RAyddk5YVgIR",
+	"hkhlsXnVLsrhdYJfMDtepsLZK/xEVGO+hEBv1CNzdeh9UBIuDz8OLnog+eVYlToKGDABLeysqWBeNBM6",
+	"ZVpis2U16bJnq1cW610oY98r/emeZB2uWV4gjBwlXeR+rDL+p7QiizgOzhZ6mGaGjCHjxGTKkhLbe7OM",
+	"hCVEWJKXxsG4kxido+WGodJAlMbPzoJnwAxwmnRcXKGFWtwwDaMyY7hlqRZWpCyLQtsttNkCy5d58A5Y",
+	"jqapUNKIqwzIUGnHEboCUN+5H+cE50yWLKMJZaVVObMipRfrsKTevo+Up4c0oTw9wh7enG2FNlHh0ypT",
+	"csQsrBDBOwpUdM7Seh/0Hr1W52r9UwoN3LGuGvciTs+HYN/a1Dhz+1IYCzzuHRSsNLCdXZkKydU0Atnv",
+	"Eb8OEL9IaJOEP8hU2DEByQlCmcPWkVNFdImYJE64/TeQ3BAJ15ZwNmuC/FqPyM3SzTxpKJS2a+wzS23J",
+	"su14wmEibmGiP8EsbjwyJiXw7QarFWFrMpo9O0uBc0K7tO1ib8IGLrvcwyGk6E891yqCZe+9dAmDWuXb",
+	"kqFWuUdyBEfy09Fg8MvB4PBgcPQzIveb56ePHj36tYJ5mWalQX8tgmD15CusSWz2hiHpMD1cr5te8hdo",
+	"+Lfw9jq3j+0J7ugfpU1VHgES7Q45UWE1ltnSNC0EamgG1htGJjL3h1ZZBvzyiqVoadiV0tjgYhPuhdEv",
+	"VhBsNiryM8m7m0zf5S3y8g7nzQ2RgGCQSGl8NKA692lnitErzJixhEPqQxPsSpW2MtEdzrLf3OnqNqmI",
+	"H9Tu4CM1cexUldLeO3LO1SIaE1BD4uU/IV78CYo/4kCQ/5WO1y3cvWU9RP+W1tYEucdkCl4dhbwstBpp",
+	"MIYmVE1A8xKHvZPuzn3ObY7mX8PJ/JoH1s+3c6U4pCrPhUGdXdUmZ5KNgC8LDLpvaElCA2LHWpWjMTl5",
+	"fUaTyEB389vu5FR5BnUMKeFPQg5V5ByCNtKAnoAmHCaQqQK4Q8C3b54Rq1FRhCRn7/5jyDshP6nhkLxV",
+	"WYndyWlZ9GhCM5GCNG6zJEOzRU8Klo6BHPUGNKGlzugxHVtbHPf70+m0x9zXntKjfuhq+i/PTp/9/vbZ",
+	"wVFv0BvbPHMLFhahj+KJYg7PtBHupYe9Qe/wEBurAiQrBD2mj3qD3iPqz/6OvX2m03GIBo0gcib4DTyw",
+	"G5KrCW67IqELGgYNFiS2TEIjVNOMFYWQI2cnNJMjIEwDNi21V3IUWgdlZ9zPcBKIQMI0y8GCNvT44xJm",
+	"ucEc8BjnRdGEwnWRuciN1SXgTtJj+k8JLjoeOI4tHVA17bZvPj/hdUGwmyROkTsRWNWNGqvuiRbUfMRz",
+	"jzidaKma3uKguzT9WwfuYJAEF76wiqBEdSLEhM5xWmJeW8NQ3MYItBdzkdAQb/BYdTQYhPyPDQdllxpJ",
+	"ndD2/w4GdU5ph1j4zU2yyLAyTcGYYekU+vFXnNJH2CJTPmGcoNiBcWblfx5izjPpQs5ZhaRVw4SaMs+Z",
+	"ngWQCajiHQ5DE2rZyDj/uko6XGCnflqHNVbiVRWJqEKYvkvEm2UYt7WGVKAZgabTKpZ7bwKykGXcIClL",
+	"fHMLLLXG9c4zN4F34YcLH4FZGfGpGSYMcSFshp4fcqdKCeIshuVApmyGgSHgyDc1bEaIEtcK//Khx6kW",
+	"1oLsncuTMEpojGaVBWMScpk4JiMSpqTO3i1uxuuyuRlOiJ8oPvtq+zAPmd20j3gIWzc7IwAPBBVnrVxG",
+	"QliWhURHUeU90Kpnzt10yQ+fJtklWHkDRcZSWEhqLqrGHFT643mueiW4sLYsV+JqqrAOno0toCmWsBpQ",
+	"XtQp/HsWq2qijVL1LXcNZz+8/9lPW+UTVQ7NlEXhj7poG6zSmFZaxtml2osOgtT/UonHTR+dE+eZ4LlN",
+	"mYh0PV0wW7qu/DArcfj1n+9ImNThtcfVzWD6JlBTI+pax/u0Les0OHEug1j7cI2vHVzblfUYFz8a0gbm",
+	"RjYdpdOjMJOzXGnwND2+f5rqoiMkwcH+HiLWQwQqlI+dBW20qrWfGwBD1MVFUcPz2gfInIq7nLA/bU/H",
+	"Ih07O4xUsixT06p8a9EpDBl8NrTo97pDIpkiTnDuHLFz6cJHzqATzLcupspca8m0xjl65D3my3xNEpBQ",
+	"hCNHgS5/3o/UFp3LRarqsqZ6GQ7CVlvOUIa1AbF+A4m9m2Q0psIYzgLN3c6pYcGtYyqHISszS4+HLDOw",
+	"HAp7AEQLPNlpG9/SllOsmSMh7FsJzYgJaboeaFB35onSqNI8F1KYcT1+NZuQC5GpkVZl4fUG1SIJ4YtZ",
+	"4QopMF7cI5UCVnkIRAYcRUk4l+E0Apwo6Tu7kkYXFmtlLoNiBCwxgCJsIZvFpP2NX9w+JHYHWl6oadj8",
+	"xj53o8k1fjKLa7oPwCd1YCr8W6cJQpbhYueidvcJRUEb95GutcCn8gJlsWgYdJ/SraGljqCuCYI1C3FX",
+	"nlSrRr62pRpxCWjqsR4EatgOQQ27N6jJmUWutmyaMzjHVYbEkAPXPhRnVJlOwiw6+yRXrZOgW+hPAX9+",
+	"Ts6l34ZLIathwr4IaQSHeafEsafRDv9datWNdzl+j+NhtagGJjZ+qol1nx05nbDxFbsOPE1VKV21a4Ve",
+	"3SjORC5snOTDwSBSNpCza5Ej/YcD/J4LGf6NFagskvtHwf4pne9ilPbhoP89+B2u7cGp/2kMDP3gsKkF",
+	"Hg1UaUjBuu6AH7q1oI1MfC7wvGAVMUpXqbyrWbf5sMtKGzgvAJhvevs3Hv6qyz26bPpbJFNpDro7jX+E",
+	"5jEymUkbBPr/cMZOxLj06hA5iBATcpghGOp/FUMCeWE7MtR3Wp/lCnUIydzON5i6WAfTYG5dX9FyROpi",
+	"lboUZKVzsjnH9/2nGL9FQcpu5SIT6mHKTd7Cr0j6yP1eAZqriUUwq68sKDkvFgsotxq5br6RbxgCahHF",
+	"d36T4ESq3YiBLcWk4x7eCp9xqvSa4HO4eIBB4+axHG1ufa+JfI7lNE44f+9rxO4lK1fd3IgF67ag+eHS",
+	"efuEf4dj0BY7V4kzNmiIcv+LN5Q3a89ArdGvZkREI4soYE9mZ3zTEeiMV6YnngKpTffqQMYi6u1E6cm/",
+	"FgCj27tJYPrepjssjBZFuM9dRMe3/NGlZ9dN9uPBr/dP4ftwCLelmd+vTsd4uuaJl1+SKu5KY87pWZbB",
+	"iGXEaiaNwCHO6W4B9Bod2Kxf/j7iGmfDN5insoiQVtWzhVuMwhqCVfsErguhYTl4FYbZo/cu6kJInbot",
+	"VnpxMzvqBPY8py8a/XZNTxYluYt+DF1iarN6+HYotC4EAI6R4dxJ/P0DN2eCBy1gOptVXXzm2dQBRB8i",
+	"zNTipeC2PvmE2d6c7c3Zd2fOljSqoT5dVDYPr5dEHcZXahISI+7WpEvq1IniodItM7qkddj7W+vc1z+3",
+	"r1S3rZjVIwsBT5Q35YZiWW9/rP9XR7C2VZuNSqoad4OjhtXXVJCxmlYWNantqQO0EMj19nLMDAHJsdop",
+	"3JZ0xtMLnCOwfXXSBXvdKkK4NzmXVc6t7dVWCwPJK2tsVtvs5eJNt4z389vQ3x90hIXFRCt8aqxmDwJ7",
+	"J+JhrhO43OSS/G0EpvkLMCt9iNd1E6/4NRBWrn/Ud3jdelrm+4KBhWdzIpvWlWd7fNjjwwPgQ1dx3AgX",
+	"pnrdY314wDVDba7ciYWQQBaeeyxcZZDb5aql7xoqYQtmrKtaCnQuoYx7bWQfFthr7PcaFqg1aWNUoH7O",
+	"I5pxxDcywkXAxr1YdyRwHWOPZySk/SxH3cb3aFyytErFkpcfQoXNnVSk03sbSFH8zbst7iv7ZYUzTv2A",
+	"1YZKf9ep/8U/63HjGY8ntdXPlGjw71XUtzIXp0rCHRhUJXfLqX1boJbu9u4sbcDTxucPPmG9Fh49fTyO",
+	"jX59O4ONfr93AhqfLeye2zdV+sraz2HPw/urW11WfpDrdG7TW1fpHgSt3bQYxViW7x/kOt+HGkM7XuNr",
+	"qvNiAUrjMYWA/lEs/hER4BvoUNSwbPH0RWUowo1pF4lzzwWYhmGor1sLvmzWhYwalh7xQ0viblSeS3eJ",
+	"0qth9exo7HGLbyY79/KUxodach7u9L3r9urOxmqP2MuIfcJdWtwfQlaCwM3Nzf8PAHENFUPpYwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
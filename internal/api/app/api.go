@@ -4,34 +4,50 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"math"
 	"net/http"
+	"strings"
 	"time"
+	"workScheduler/internal/confighistory"
 	"workScheduler/internal/configuration"
+	"workScheduler/internal/report"
 	"workScheduler/internal/repository"
 	"workScheduler/internal/scheduler/app"
 	"workScheduler/internal/scheduler/models"
+	"workScheduler/internal/zones"
 
 	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
 )
 
 type Api struct {
 	RepoData   repository.ReadWriteRepository
+	Archive    repository.Archive
 	Scheduller *app.Scheduler
 	Config     *configuration.Configurator
+	// History is nil if storage doesn't keep config history
+	History *confighistory.Recorder
+	// Zones is nil if storage doesn't keep zones
+	Zones *zones.Syncer
 }
 
-func NewApi(repo repository.ReadWriteRepository, scheduler *app.Scheduler, config *configuration.Configurator) *Api {
+func NewApi(repo repository.ReadWriteRepository, archive repository.Archive, scheduler *app.Scheduler, config *configuration.Configurator, history *confighistory.Recorder, zones *zones.Syncer) *Api {
 	return &Api{
 		RepoData:   repo,
+		Archive:    archive,
 		Scheduller: scheduler,
 		Config:     config,
+		History:    history,
+		Zones:      zones,
 	}
 }
 
 type ErrorStruct struct {
-	Alternative []*models.WorkItem `json:"alternative,omitempty"`
-	ErrorCode   string             `json:"errorCode,omitempty"`
-	Message     string             `json:"message,omitempty"`
+	Alternative []*models.WorkItem    `json:"alternative,omitempty"`
+	ErrorCode   string                `json:"errorCode,omitempty"`
+	Message     string                `json:"message,omitempty"`
+	Issues      []configuration.Issue `json:"issues,omitempty"`
 }
 
 func inArray(arr []string, i []string) bool {
@@ -56,8 +72,13 @@ func inWhiteList(whitelist map[string][]configuration.Window, zones []string) bo
 	return false
 }
 
-func (a *Api) validateAddWork(work *models.WorkItem) error {
+// validateAddWork checks work against config, start of started works (e.g. prolongated) may be in
+// past.
+func (a *Api) validateAddWork(work *models.WorkItem, started bool) error {
 	ts := time.Now()
+	// work is checked against rules in effect at its start
+	conf := a.Config.Current().At(work.StartDate)
+	limits := conf.Limits(work.Zones, work.WorkType, work.Priority)
 	errStr := ""
 	fmt.Println(work)
 	if work.Priority != "regular" && work.Priority != "critical" {
@@ -72,30 +93,30 @@ func (a *Api) validateAddWork(work *models.WorkItem) error {
 	}
 
 	delta := work.Deadline.Sub(ts)
-	if delta.Hours() <= 0 || int32(delta.Hours()/24) > a.Config.Data.MaxDeadlineDays {
-		errStr += "Deadline can't be greater then now for 4 week; "
+	if delta.Hours() <= 0 || int32(delta.Hours()/24) > limits.MaxDeadlineDays {
+		errStr += fmt.Sprintf("Deadline can't be greater then now for %d days; ", limits.MaxDeadlineDays)
 	}
-	if inArray(work.Zones, a.Config.Data.BlackList) && work.Priority != "critical" {
+	if inArray(work.Zones, conf.BlackList) && work.Priority != "critical" {
 		errStr += "Can't schedule work for zone in blacklist , excepted critical work; "
 	}
-	if !inWhiteList(a.Config.Data.WhiteList, work.Zones) && work.Priority != "critical" {
+	if !inWhiteList(conf.WhiteList, work.Zones) && work.Priority != "critical" {
 		errStr += "Can't schedule work for zone not in whitelist, excepted critical work; "
 	}
 
-	if work.StartDate.Unix() <= ts.Unix() {
+	if !started && work.StartDate.Unix() <= ts.Unix() {
 		errStr += "Start Date can't be in past; "
 	}
-	if work.DurationMinutes < a.Scheduller.Config.MinWorkDurationMinutes.Automatic && work.WorkType == "automatic" {
-		errStr += fmt.Sprintf("Automatic work duration can't be lower then %d minutes; ", a.Scheduller.Config.MinWorkDurationMinutes.Automatic)
+	if work.DurationMinutes < limits.MinWorkDurationMinutes && work.WorkType == "automatic" {
+		errStr += fmt.Sprintf("Automatic work duration can't be lower then %d minutes; ", limits.MinWorkDurationMinutes)
 	}
-	if work.DurationMinutes < a.Scheduller.Config.MinWorkDurationMinutes.Manual && work.WorkType == "manual" {
-		errStr += fmt.Sprintf("Manual work duration can't be lower then %d minutes; ", a.Scheduller.Config.MinWorkDurationMinutes.Manual)
+	if work.DurationMinutes < limits.MinWorkDurationMinutes && work.WorkType == "manual" {
+		errStr += fmt.Sprintf("Manual work duration can't be lower then %d minutes; ", limits.MinWorkDurationMinutes)
 	}
-	if work.DurationMinutes > a.Scheduller.Config.MaxWorkDurationMinutes.Manual && work.Priority != "critical" {
-		errStr += fmt.Sprintf("Manual work max duration can't be greater then %d minutes, excepted critical work; ", a.Scheduller.Config.MaxWorkDurationMinutes.Manual)
+	if work.DurationMinutes > limits.MaxWorkDurationMinutes && work.WorkType == "manual" && work.Priority != "critical" {
+		errStr += fmt.Sprintf("Manual work max duration can't be greater then %d minutes, excepted critical work; ", limits.MaxWorkDurationMinutes)
 	}
-	if work.DurationMinutes > a.Scheduller.Config.MaxWorkDurationMinutes.Automatic && work.Priority != "critical" {
-		errStr += fmt.Sprintf("Automatic work max duration can't be greater then %d minutes, excepted critical work; ", a.Scheduller.Config.MaxWorkDurationMinutes.Automatic)
+	if work.DurationMinutes > limits.MaxWorkDurationMinutes && work.WorkType == "automatic" && work.Priority != "critical" {
+		errStr += fmt.Sprintf("Automatic work max duration can't be greater then %d minutes, excepted critical work; ", limits.MaxWorkDurationMinutes)
 	}
 	if work.StartDate.Minute()%5 != 0 && work.WorkType == "manual" {
 		errStr += "Manual work started time must be multiple by 5 minutes; "
@@ -157,13 +178,84 @@ func (a *Api) Getschedule(w http.ResponseWriter, r *http.Request, params Getsche
 		}
 	}
 
-	works, err := a.RepoData.List(r.Context(), *params.FromDate, *params.ToDate, zones, statuses)
+	query := repository.PageQuery{
+		From:     *params.FromDate,
+		To:       *params.ToDate,
+		Zones:    zones,
+		Statuses: statuses,
+	}
+	if params.Mode != nil {
+		query.Mode = repository.ListMode(*params.Mode)
+	}
+	if params.Limit != nil {
+		query.Limit = int(*params.Limit)
+	}
+	if params.Cursor != nil {
+		query.Cursor = *params.Cursor
+	}
+	if params.SortBy != nil {
+		query.SortBy = repository.SortField(*params.SortBy)
+	}
+	if params.SortOrder != nil {
+		query.SortOrder = repository.SortOrder(*params.SortOrder)
+	}
+	if params.Fields != nil {
+		for _, f := range *params.Fields {
+			query.Fields = append(query.Fields, string(f))
+		}
+	}
+	if _, err := query.Normalize(); err != nil {
+		a.writeError(w, http.StatusBadRequest, "Bad request", err, []*models.WorkItem{})
+		return
+	}
+
+	page, err := a.RepoData.ListPage(r.Context(), query)
 	if err != nil {
 		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
 		return
 	}
 
-	works_b, err := json.Marshal(works)
+	works_b, err := marshalWorks(page.Works, query.Fields)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(works_b)
+}
+
+func (a *Api) GetArchive(w http.ResponseWriter, r *http.Request, params GetArchiveParams) {
+	defer r.Body.Close()
+
+	var statuses []string
+	var zones []string
+
+	if !params.FromDate.Before(params.ToDate) {
+		a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("FromDate must be before ToDate"), []*models.WorkItem{})
+		return
+	}
+
+	if params.Statuses != nil {
+		for _, s := range *params.Statuses {
+			statuses = append(statuses, string(s))
+		}
+	}
+
+	if params.Zones != nil {
+		zones = *params.Zones
+	}
+
+	works, err := a.Archive.ListArchived(r.Context(), params.FromDate, params.ToDate, zones, statuses)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	works_b, err := marshalWorks(works, nil)
 	if err != nil {
 		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
 		return
@@ -173,6 +265,105 @@ func (a *Api) Getschedule(w http.ResponseWriter, r *http.Request, params Getsche
 	w.Write(works_b)
 }
 
+func (a *Api) GetReport(w http.ResponseWriter, r *http.Request, params GetReportParams) {
+	defer r.Body.Close()
+
+	var zones []string
+
+	if !params.FromDate.Before(params.ToDate) {
+		a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("FromDate must be before ToDate"), []*models.WorkItem{})
+		return
+	}
+
+	groupBy := report.GroupByZone
+	if params.GroupBy != nil {
+		groupBy = report.GroupBy(*params.GroupBy)
+	}
+
+	if params.Zones != nil {
+		zones = *params.Zones
+	}
+
+	statuses := []string{string(models.StatusCompleted), string(models.StatusFailed), string(models.StatusRolledBack), string(models.StatusAborted)}
+	works, err := a.RepoData.List(r.Context(), params.FromDate, params.ToDate, zones, statuses, repository.ListModeOverlaps)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	rows, err := report.Build(works, groupBy)
+	if err != nil {
+		a.writeError(w, http.StatusBadRequest, "Bad request", err, []*models.WorkItem{})
+		return
+	}
+	if groupBy == report.GroupByZone && len(zones) > 0 {
+		// works in several zones are listed by any of them
+		requested := []report.Row{}
+		for _, row := range rows {
+			if slices.Contains(zones, row.Key) {
+				requested = append(requested, row)
+			}
+		}
+		rows = requested
+	}
+
+	rows_b, err := json.Marshal(rows)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(rows_b)
+}
+
+func (a *Api) GetConfigImpact(w http.ResponseWriter, r *http.Request, params GetConfigImpactParams) {
+	defer r.Body.Close()
+
+	propose := params.Propose != nil && *params.Propose
+	impact, err := a.Scheduller.Impact(a.Config.Current(), time.Now(), propose)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	impact_b, err := json.Marshal(impact)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(impact_b)
+}
+
+// marshalWorks encodes works with only given json fields, all fields if fields are empty.
+func marshalWorks(works []*models.WorkItem, fields []string) ([]byte, error) {
+	if len(fields) == 0 {
+		if works == nil {
+			works = []*models.WorkItem{}
+		}
+		return json.Marshal(works)
+	}
+	projected := make([]map[string]json.RawMessage, 0, len(works))
+	for _, work := range works {
+		work_b, err := json.Marshal(work)
+		if err != nil {
+			return nil, err
+		}
+		all := map[string]json.RawMessage{}
+		if err := json.Unmarshal(work_b, &all); err != nil {
+			return nil, err
+		}
+		selected := map[string]json.RawMessage{}
+		for _, f := range fields {
+			selected[f] = all[f]
+		}
+		projected = append(projected, selected)
+	}
+	return json.Marshal(projected)
+}
+
 func (a *Api) AddWork(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 	work := &models.WorkItem{}
@@ -183,12 +374,26 @@ func (a *Api) AddWork(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := a.validateAddWork(work); err != nil {
+	if err := a.validateAddWork(work, false); err != nil {
 		a.writeError(w, http.StatusBadRequest, "Bad request", err, []*models.WorkItem{})
 		return
 	}
 
 	work.WorkId = uuid.New().String()
+	// status and execution facts are set only by the service
+	work.Status, work.Reason = "", ""
+	work.ActualStart, work.ActualEnd = time.Time{}, time.Time{}
+	work.ProlongationCount, work.ProlongationMinutes = 0, 0
+	if !work.HoldUntil.IsZero() {
+		if !work.HoldUntil.After(time.Now()) || work.HoldUntil.After(work.StartDate) {
+			a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("HoldUntil must be in future and not later than StartDate"), []*models.WorkItem{})
+			return
+		}
+		if err := work.ChangeStatus(models.StatusHeld, models.ActorUser); err != nil {
+			a.writeError(w, http.StatusConflict, "Illegal transition", err, []*models.WorkItem{})
+			return
+		}
+	}
 	work.InitialDuration = work.DurationMinutes
 	work.InitialStartDate = work.StartDate
 	work.CompressionRate = 1
@@ -235,6 +440,10 @@ func (a *Api) GetWorkById(w http.ResponseWriter, r *http.Request, workId string)
 	defer r.Body.Close()
 
 	work, err := a.RepoData.GetById(r.Context(), workId)
+	if repository.IsNotFound(err) {
+		a.writeError(w, http.StatusNotFound, "Not found", err, []*models.WorkItem{})
+		return
+	}
 	if err != nil {
 		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
 		return
@@ -253,13 +462,24 @@ func (a *Api) CancelWorkById(w http.ResponseWriter, r *http.Request, workId stri
 	defer r.Body.Close()
 
 	works, err := a.RepoData.GetById(r.Context(), workId)
+	if repository.IsNotFound(err) {
+		a.writeError(w, http.StatusNotFound, "Not found", err, []*models.WorkItem{})
+		return
+	}
 	if err != nil {
 		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
 		return
 	}
 
+	// all parts are checked before any of them is saved
+	for _, work := range works {
+		if err = work.ChangeStatus(models.StatusCanceled, models.ActorUser); err != nil {
+			a.writeError(w, http.StatusConflict, "Illegal transition", err, []*models.WorkItem{})
+			return
+		}
+	}
+
 	for idx := range works {
-		works[idx].Status = "canceled"
 		works[idx], err = a.RepoData.Update(r.Context(), works[idx])
 		if err != nil {
 			a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
@@ -280,6 +500,10 @@ func (a *Api) CancelWorkById(w http.ResponseWriter, r *http.Request, workId stri
 func (a *Api) MoveWorkById(w http.ResponseWriter, r *http.Request, workId string) {
 	defer r.Body.Close()
 	works, err := a.RepoData.GetById(r.Context(), workId)
+	if repository.IsNotFound(err) {
+		a.writeError(w, http.StatusNotFound, "Not found", err, []*models.WorkItem{})
+		return
+	}
 	if err != nil {
 		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
 		return
@@ -292,11 +516,11 @@ func (a *Api) MoveWorkById(w http.ResponseWriter, r *http.Request, workId string
 		return
 	}
 
-	planned := false
-
 	for _, work := range works {
-		if work.Status == "planned" {
-			planned = true
+		// moved work is planned again, so works which can't be planned by user can't be moved
+		if err = work.CanChangeStatus(models.StatusPlanned, models.ActorUser); err != nil {
+			a.writeError(w, http.StatusConflict, "Illegal transition", err, []*models.WorkItem{})
+			return
 		}
 		work.StartDate = w_b.StartDate
 
@@ -304,17 +528,12 @@ func (a *Api) MoveWorkById(w http.ResponseWriter, r *http.Request, workId string
 			work.DurationMinutes = w_b.DurationMinutes
 		}
 
-		if err := a.validateAddWork(work); err != nil {
+		if err := a.validateAddWork(work, false); err != nil {
 			a.writeError(w, http.StatusBadRequest, "Bad request", err, []*models.WorkItem{})
 			return
 		}
 	}
 
-	if !planned {
-		a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("Can't move work with status != planned"), []*models.WorkItem{})
-		return
-	}
-
 	works, needUserApprove, err := a.Scheduller.MoveWork(works)
 	if err != nil {
 		if needUserApprove {
@@ -342,31 +561,288 @@ func (a *Api) MoveWorkById(w http.ResponseWriter, r *http.Request, workId string
 	w.Write(work_b)
 }
 
-func (a *Api) ProlongateWorkById(w http.ResponseWriter, r *http.Request, workId string) {
+func (a *Api) ConfirmWorkById(w http.ResponseWriter, r *http.Request, workId string) {
 	defer r.Body.Close()
 
-	w_b := &models.WorkItem{}
-	err := json.NewDecoder(r.Body).Decode(w_b)
+	works, err := a.RepoData.GetById(r.Context(), workId)
+	if repository.IsNotFound(err) {
+		a.writeError(w, http.StatusNotFound, "Not found", err, []*models.WorkItem{})
+		return
+	}
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	now := time.Now()
+	for _, work := range works {
+		if err = work.CanChangeStatus(models.StatusPlanned, models.ActorUser); err != nil {
+			a.writeError(w, http.StatusConflict, "Illegal transition", err, []*models.WorkItem{})
+			return
+		}
+		if work.Status == models.StatusHeld && !now.Before(work.HoldUntil) {
+			a.writeError(w, http.StatusConflict, "Hold expired", fmt.Errorf("Hold of work %s expired at %s", work.WorkId, work.HoldUntil), []*models.WorkItem{})
+			return
+		}
+	}
+
+	for _, work := range works {
+		if err = work.ChangeStatus(models.StatusPlanned, models.ActorUser); err != nil {
+			a.writeError(w, http.StatusConflict, "Illegal transition", err, []*models.WorkItem{})
+			return
+		}
+		work.HoldUntil = time.Time{}
+		if _, err = a.RepoData.Update(r.Context(), work); err != nil {
+			a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+			return
+		}
+	}
+
+	works_b, err := json.Marshal(works)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(works_b)
+}
+
+func (a *Api) StartWorkById(w http.ResponseWriter, r *http.Request, workId string) {
+	defer r.Body.Close()
+
+	works, err := a.RepoData.GetById(r.Context(), workId)
+	if repository.IsNotFound(err) {
+		a.writeError(w, http.StatusNotFound, "Not found", err, []*models.WorkItem{})
+		return
+	}
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	now := time.Now()
+	started := []*models.WorkItem{}
+	for _, work := range works {
+		if work.WorkType != app.WorkTypeManual {
+			a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("Only manual work may be started"), []*models.WorkItem{})
+			return
+		}
+		// work parts in other zones may be planned later, they are started by their own call
+		if work.Status == app.StatusInProgress || now.Before(work.StartDate) {
+			continue
+		}
+		if err = work.ChangeStatus(app.StatusInProgress, models.ActorUser); err != nil {
+			a.writeError(w, http.StatusConflict, "Illegal transition", err, []*models.WorkItem{})
+			return
+		}
+		work.ActualStart = now
+		started = append(started, work)
+	}
+	if len(started) == 0 {
+		a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("Only planned work may be started after its planned start"), []*models.WorkItem{})
+		return
+	}
+
+	for _, work := range started {
+		if _, err = a.RepoData.Update(r.Context(), work); err != nil {
+			a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+			return
+		}
+	}
+
+	works_b, err := json.Marshal(works)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(works_b)
+}
+
+func (a *Api) FinishWorkById(w http.ResponseWriter, r *http.Request, workId string) {
+	defer r.Body.Close()
+
+	works, err := a.RepoData.GetById(r.Context(), workId)
+	if repository.IsNotFound(err) {
+		a.writeError(w, http.StatusNotFound, "Not found", err, []*models.WorkItem{})
+		return
+	}
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	now := time.Now()
+	finished := []*models.WorkItem{}
+	var transitionErr error
+	for _, work := range works {
+		if work.WorkType != app.WorkTypeManual {
+			a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("Only manual work may be finished"), []*models.WorkItem{})
+			return
+		}
+		if work.Status == app.StatusCompleted {
+			continue
+		}
+		// not started parts in other zones are kept
+		if err := work.ChangeStatus(app.StatusCompleted, models.ActorUser); err != nil {
+			transitionErr = err
+			continue
+		}
+		releaseRest(work, now)
+		work.ActualEnd = now
+		finished = append(finished, work)
+	}
+	if len(finished) == 0 && transitionErr != nil {
+		a.writeError(w, http.StatusConflict, "Illegal transition", transitionErr, []*models.WorkItem{})
+		return
+	}
+	if len(finished) == 0 {
+		a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("Work is already finished"), []*models.WorkItem{})
+		return
+	}
+
+	for _, work := range finished {
+		if _, err = a.RepoData.Update(r.Context(), work); err != nil {
+			a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+			return
+		}
+	}
+
+	works_b, err := json.Marshal(works)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(works_b)
+}
+
+func (a *Api) ReportWorkOutcomeById(w http.ResponseWriter, r *http.Request, workId string) {
+	defer r.Body.Close()
+
+	outcome := &WorkOutcome{}
+	err := json.NewDecoder(r.Body).Decode(outcome)
 	if err != nil {
 		a.writeError(w, http.StatusBadRequest, "Bad request", err, []*models.WorkItem{})
 		return
 	}
+	switch outcome.Status {
+	case WorkOutcomeStatusCompleted:
+	case WorkOutcomeStatusFailed, WorkOutcomeStatusRolledBack, WorkOutcomeStatusAborted:
+		if outcome.Reason == nil || strings.TrimSpace(*outcome.Reason) == "" {
+			a.writeError(w, http.StatusBadRequest, "Bad request", fmt.Errorf("Reason is required for %s work", outcome.Status), []*models.WorkItem{})
+			return
+		}
+	default:
+		a.writeError(w, http.StatusBadRequest, "Bad request", fmt.Errorf("Unknown outcome status %q", outcome.Status), []*models.WorkItem{})
+		return
+	}
 
 	works, err := a.RepoData.GetById(r.Context(), workId)
+	if repository.IsNotFound(err) {
+		a.writeError(w, http.StatusNotFound, "Not found", err, []*models.WorkItem{})
+		return
+	}
 	if err != nil {
 		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
 		return
 	}
 
-	if len(works) == 0 {
-		w.WriteHeader(http.StatusNotFound)
+	now := time.Now()
+	status := models.Status(outcome.Status)
+	reported := []*models.WorkItem{}
+	var transitionErr error
+	for _, work := range works {
+		if work.Status == status {
+			continue
+		}
+		// automatic works are completed by actualizer at their end, executor may report outcome later
+		completed := work.Status == app.StatusCompleted
+		if err := work.ChangeStatus(status, models.ActorExecutor); err != nil {
+			transitionErr = err
+			continue
+		}
+		if !completed {
+			releaseRest(work, now)
+			work.ActualEnd = now
+		}
+		if outcome.Reason != nil {
+			work.Reason = *outcome.Reason
+		}
+		reported = append(reported, work)
+	}
+	if len(reported) == 0 && transitionErr != nil {
+		a.writeError(w, http.StatusConflict, "Illegal transition", transitionErr, []*models.WorkItem{})
+		return
+	}
+	if len(reported) == 0 {
+		a.writeError(w, http.StatusBadRequest, "Bad request", fmt.Errorf("Work is already %s", status), []*models.WorkItem{})
+		return
+	}
+
+	for _, work := range reported {
+		if _, err = a.RepoData.Update(r.Context(), work); err != nil {
+			a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+			return
+		}
+	}
+
+	works_b, err := json.Marshal(works)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(works_b)
+}
+
+// releaseRest shortens work ended before its planned end to the actual duration, so the rest
+// of the slot is available for other works.
+func releaseRest(work *models.WorkItem, now time.Time) {
+	if !now.Before(work.EndTime()) {
+		return
+	}
+	work.DurationMinutes = int32(math.Ceil(now.Sub(work.StartDate).Minutes()))
+	if work.DurationMinutes < 1 {
+		work.DurationMinutes = 1
+	}
+}
+
+func (a *Api) ProlongateWorkById(w http.ResponseWriter, r *http.Request, workId string) {
+	defer r.Body.Close()
+
+	w_b := &models.WorkItem{}
+	err := json.NewDecoder(r.Body).Decode(w_b)
+	if err != nil {
+		a.writeError(w, http.StatusBadRequest, "Bad request", err, []*models.WorkItem{})
+		return
+	}
+
+	works, err := a.RepoData.GetById(r.Context(), workId)
+	if repository.IsNotFound(err) {
+		a.writeError(w, http.StatusNotFound, "Not found", err, []*models.WorkItem{})
+		return
+	}
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
 		return
 	}
 
 	in_progress := false
 
 	for _, work := range works {
-		if work.Status == "in_progress" {
+		// overdue work is started again by prolongation
+		if work.Status == app.StatusOverdue {
+			if err := work.ChangeStatus(app.StatusInProgress, models.ActorUser); err != nil {
+				a.writeError(w, http.StatusConflict, "Illegal transition", err, []*models.WorkItem{})
+				return
+			}
+		}
+		if work.Status == app.StatusInProgress {
 			in_progress = true
 		}
 
@@ -375,15 +851,15 @@ func (a *Api) ProlongateWorkById(w http.ResponseWriter, r *http.Request, workId
 			return
 		}
 
-		work.DurationMinutes = w_b.DurationMinutes
-		if err := a.validateAddWork(work); err != nil {
+		work.Prolongate(w_b.DurationMinutes)
+		if err := a.validateAddWork(work, work.Status == app.StatusInProgress); err != nil {
 			a.writeError(w, http.StatusBadRequest, "Bad request", err, []*models.WorkItem{})
 			return
 		}
 	}
 
 	if !in_progress {
-		a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("Can't prolongate work with status != in_progress"), []*models.WorkItem{})
+		a.writeError(w, http.StatusBadRequest, "Bad request", errors.New("Can't prolongate work with status other than in_progress or overdue"), []*models.WorkItem{})
 		return
 	}
 
@@ -417,3 +893,216 @@ func (a *Api) ProlongateWorkById(w http.ResponseWriter, r *http.Request, workId
 	w.WriteHeader(http.StatusOK)
 	w.Write(works_b)
 }
+
+func (a *Api) GetConfig(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	a.writeConfig(w, a.Config.Current(), 0)
+}
+
+func (a *Api) PutConfig(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body := &PutConfig{}
+	err := json.NewDecoder(r.Body).Decode(body)
+	if err != nil {
+		a.writeError(w, http.StatusBadRequest, "Bad request", err, []*models.WorkItem{})
+		return
+	}
+
+	conf, err := a.Config.Apply([]byte(body.Document))
+	if err != nil {
+		a.writeConfigError(w, err)
+		return
+	}
+	// config is already applied, so history error doesn't fail the request, revision is omitted then
+	var revision repository.ConfigRevision
+	if a.History != nil {
+		revision, _, err = a.History.Record(r.Context(), conf)
+		if err != nil {
+			log.Printf("WARNING: Find error while saving applied config version %d to history, %s\n", conf.Version, err)
+		}
+	}
+	a.writeConfig(w, conf, revision.Revision)
+}
+
+func (a *Api) GetConfigHistory(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	if a.History == nil {
+		a.writeError(w, http.StatusNotImplemented, "Not supported", errors.New("Config history is not supported by storage"), []*models.WorkItem{})
+		return
+	}
+
+	revisions, err := a.History.Repository.ListConfigs(r.Context())
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	revisions_b, err := json.Marshal(revisions)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(revisions_b)
+}
+
+func (a *Api) RollbackConfig(w http.ResponseWriter, r *http.Request, revision int64) {
+	defer r.Body.Close()
+	if a.History == nil {
+		a.writeError(w, http.StatusNotImplemented, "Not supported", errors.New("Config history is not supported by storage"), []*models.WorkItem{})
+		return
+	}
+
+	conf, saved, err := a.History.Rollback(r.Context(), revision)
+	if repository.IsNotFound(err) {
+		a.writeError(w, http.StatusNotFound, "Not found", err, []*models.WorkItem{})
+		return
+	}
+	if err != nil {
+		a.writeConfigError(w, err)
+		return
+	}
+	a.writeConfig(w, conf, saved.Revision)
+}
+
+func (a *Api) writeConfig(w http.ResponseWriter, conf *configuration.Config, revision int64) {
+	document := string(conf.Document)
+	result := ConfigDocument{
+		Version:  &conf.Version,
+		Document: &document,
+	}
+	if revision != 0 {
+		result.Revision = &revision
+	}
+
+	conf_b, err := json.Marshal(result)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(conf_b)
+}
+
+// writeConfigError lists all problems of invalid config, so they can be fixed at once.
+func (a *Api) writeConfigError(w http.ResponseWriter, err error) {
+	var invalid *configuration.ValidationError
+	if !errors.As(err, &invalid) {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+	w.WriteHeader(http.StatusBadRequest)
+	errBytes, e := json.Marshal(ErrorStruct{
+		Message:   "Config is not applied",
+		ErrorCode: "Invalid config",
+		Issues:    invalid.Issues,
+	})
+	if e != nil {
+		w.Write([]byte(e.Error()))
+	} else {
+		w.Write(errBytes)
+	}
+}
+
+func (a *Api) GetZones(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	zones_b, err := json.Marshal(zones.Effective(a.Config.Current(), a.Config.Zones()))
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(zones_b)
+}
+
+func (a *Api) GetZone(w http.ResponseWriter, r *http.Request, zoneId string) {
+	defer r.Body.Close()
+
+	state, ok := a.findZone(zoneId)
+	if !ok {
+		a.writeError(w, http.StatusNotFound, "Not found", fmt.Errorf("zone %s not found", zoneId), []*models.WorkItem{})
+		return
+	}
+	a.writeZone(w, state)
+}
+
+func (a *Api) PutZone(w http.ResponseWriter, r *http.Request, zoneId string) {
+	defer r.Body.Close()
+	if a.Zones == nil {
+		a.writeError(w, http.StatusNotImplemented, "Not supported", errors.New("Zones are not supported by storage"), []*models.WorkItem{})
+		return
+	}
+
+	zone := configuration.Zone{}
+	err := json.NewDecoder(r.Body).Decode(&zone)
+	if err != nil {
+		a.writeError(w, http.StatusBadRequest, "Bad request", err, []*models.WorkItem{})
+		return
+	}
+	zone.ZoneId = zoneId
+	zone.Decommissioned = false
+	if zone.BlackListed {
+		zone.Windows = nil
+	}
+
+	if _, err = a.Zones.Save(r.Context(), zone); err != nil {
+		a.writeConfigError(w, err)
+		return
+	}
+	state, _ := a.findZone(zoneId)
+	a.writeZone(w, state)
+}
+
+func (a *Api) DecommissionZone(w http.ResponseWriter, r *http.Request, zoneId string) {
+	defer r.Body.Close()
+	if a.Zones == nil {
+		a.writeError(w, http.StatusNotImplemented, "Not supported", errors.New("Zones are not supported by storage"), []*models.WorkItem{})
+		return
+	}
+	if state, ok := a.findZone(zoneId); !ok || state.Decommissioned {
+		a.writeError(w, http.StatusNotFound, "Not found", fmt.Errorf("zone %s not found", zoneId), []*models.WorkItem{})
+		return
+	}
+
+	statuses := []string{string(models.StatusHeld), string(models.StatusPlanned), string(models.StatusInProgress), string(models.StatusOverdue)}
+	works, err := a.RepoData.List(r.Context(), time.Unix(0, 0), time.Now().Add(24*time.Hour*time.Duration(a.Config.Current().LongestDeadlineDays())), []string{zoneId}, statuses, repository.ListModeOverlaps)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+	if len(works) > 0 {
+		a.writeError(w, http.StatusConflict, "Zone is in use", fmt.Errorf("zone %s has %d not finished works, move or cancel them first", zoneId, len(works)), works)
+		return
+	}
+
+	if _, err = a.Zones.Save(r.Context(), configuration.Zone{ZoneId: zoneId, Decommissioned: true}); err != nil {
+		a.writeConfigError(w, err)
+		return
+	}
+	state, _ := a.findZone(zoneId)
+	a.writeZone(w, state)
+}
+
+func (a *Api) findZone(zoneId string) (state zones.State, ok bool) {
+	for _, s := range zones.Effective(a.Config.Current(), a.Config.Zones()) {
+		if s.ZoneId == zoneId {
+			return s, true
+		}
+	}
+	return
+}
+
+func (a *Api) writeZone(w http.ResponseWriter, state zones.State) {
+	zone_b, err := json.Marshal(state)
+	if err != nil {
+		a.writeError(w, http.StatusInternalServerError, "Internal error", err, []*models.WorkItem{})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(zone_b)
+}
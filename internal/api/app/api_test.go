@@ -0,0 +1,557 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"workScheduler/internal/confighistory"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+	filearchive "workScheduler/internal/repository/file_archive"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
+	"workScheduler/internal/scheduler/app"
+	"workScheduler/internal/scheduler/models"
+	"workScheduler/internal/zones"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	testConfigName = "../../scheduler/test_configs/scheduler_pre_final_config.yml"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *inmemoryrepository.InMemoryRepository) {
+	return newTestServerWithHistory(t, nil)
+}
+
+// newTestServerWithHistory keeps config history in history, or in the storage if it is nil
+func newTestServerWithHistory(t *testing.T, history repository.ConfigHistoryRepository) (*httptest.Server, *inmemoryrepository.InMemoryRepository) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// config is copied, because it is rewritten by PUT /config
+	document, err := os.ReadFile(testConfigName)
+	if err != nil {
+		t.Fatalf("unable to read config: %v", err)
+	}
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(configPath, document, 0644); err != nil {
+		t.Fatalf("unable to copy config: %v", err)
+	}
+	c := configuration.NewConfigurator(ctx, configPath)
+	c.Run()
+
+	repo := inmemoryrepository.NewInmemoryRepository()
+	scheduler := app.NewScheduler(ctx, repo, c)
+	archive, err := filearchive.NewFileArchive(t.TempDir())
+	if err != nil {
+		t.Fatalf("unable to create archive: %v", err)
+	}
+	if history == nil {
+		history = repo
+	}
+	api := NewApi(repo, archive, scheduler, c, confighistory.NewRecorder(history, c), zones.NewSyncer(repo, c))
+
+	server := httptest.NewServer(HandlerFromMux(api, mux.NewRouter()))
+	t.Cleanup(server.Close)
+	return server, repo
+}
+
+// failingHistory doesn't save config revisions
+type failingHistory struct{}
+
+func (failingHistory) SaveConfig(ctx context.Context, document string, previous int64) (repository.ConfigRevision, error) {
+	return repository.ConfigRevision{}, errors.New("history is unavailable")
+}
+
+func (failingHistory) ListConfigs(ctx context.Context) ([]repository.ConfigRevision, error) {
+	return nil, errors.New("history is unavailable")
+}
+
+func (failingHistory) GetConfig(ctx context.Context, revision int64) (repository.ConfigRevision, error) {
+	return repository.ConfigRevision{}, errors.New("history is unavailable")
+}
+
+func doRequest(t *testing.T, method string, url string, body any) (int, []*models.WorkItem) {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatalf("unable to encode request body: %v", err)
+		}
+	}
+	req, err := http.NewRequest(method, url, &reqBody)
+	if err != nil {
+		t.Fatalf("unable to create request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unable to do request %s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	works := []*models.WorkItem{}
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&works); err != nil {
+			t.Fatalf("unable to decode response of %s %s: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode, works
+}
+
+func TestWorkLifecycle(t *testing.T) {
+
+	t.Run("succees add, get, list and cancel work", func(t *testing.T) {
+		server, repo := newTestServer(t)
+
+		tomorrow := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+		newWork := &models.WorkItem{
+			Zones:           []string{"Zone_2", "Zone_4"},
+			StartDate:       tomorrow.Add(1 * time.Hour),
+			DurationMinutes: 60,
+			Deadline:        tomorrow.Add(5 * time.Hour),
+			Priority:        "regular",
+			WorkType:        "manual",
+		}
+
+		status, added := doRequest(t, http.MethodPost, server.URL+"/work", newWork)
+		if status != http.StatusOK {
+			t.Fatalf("unexpected add status: %v", status)
+		}
+		if len(added) != 1 || added[0].WorkId == "" || added[0].Status != "planned" {
+			t.Fatalf("unexpected added works: %v", added)
+		}
+		if added[0].ConfigVersion == 0 {
+			t.Errorf("config version of scheduling decision is not recorded: %v", added[0])
+		}
+		workId := added[0].WorkId
+		if stored, _ := repo.GetById(context.Background(), workId); len(stored) != 1 {
+			t.Fatalf("unexpected stored works count: %v, want 1", len(stored))
+		}
+
+		status, got := doRequest(t, http.MethodGet, server.URL+"/work/"+workId, nil)
+		if status != http.StatusOK || len(got) != 1 || !got[0].StartDate.Equal(newWork.StartDate) {
+			t.Errorf("unexpected get result: %v %v", status, got)
+		}
+
+		query := url.Values{}
+		query.Set("fromDate", tomorrow.Format(time.RFC3339))
+		query.Set("toDate", tomorrow.Add(24*time.Hour).Format(time.RFC3339))
+		query.Set("zones", "Zone_4")
+		status, listed := doRequest(t, http.MethodGet, server.URL+"/schedule?"+query.Encode(), nil)
+		if status != http.StatusOK || len(listed) != 1 || listed[0].WorkId != workId {
+			t.Errorf("unexpected schedule result: %v %v", status, listed)
+		}
+
+		status, canceled := doRequest(t, http.MethodPut, server.URL+"/work/"+workId+"/cancel", nil)
+		if status != http.StatusOK || len(canceled) != 1 || canceled[0].Status != "canceled" {
+			t.Errorf("unexpected cancel result: %v %v", status, canceled)
+		}
+		if stored, _ := repo.GetById(context.Background(), workId); len(stored) != 1 || stored[0].Status != "canceled" {
+			t.Errorf("unexpected stored work after cancel: %v", stored)
+		}
+	})
+
+	t.Run("succees start and early finish manual work", func(t *testing.T) {
+		server, repo := newTestServer(t)
+
+		now := time.Now().UTC()
+		repo.Add(context.Background(), &models.WorkItem{
+			WorkId:          "manual",
+			Zones:           []string{"Zone_1"},
+			StartDate:       now.Add(-10 * time.Minute),
+			DurationMinutes: 120,
+			InitialDuration: 120,
+			Status:          "planned",
+			WorkType:        "manual",
+		})
+		repo.Add(context.Background(), &models.WorkItem{
+			WorkId:          "automatic",
+			Zones:           []string{"Zone_2"},
+			StartDate:       now.Add(-10 * time.Minute),
+			DurationMinutes: 120,
+			Status:          "planned",
+			WorkType:        "automatic",
+		})
+
+		if status, _ := doRequest(t, http.MethodPost, server.URL+"/work/manual/finish", nil); status != http.StatusConflict {
+			t.Errorf("unexpected finish of not started work status: %v", status)
+		}
+		if status, _ := doRequest(t, http.MethodPost, server.URL+"/work/automatic/start", nil); status != http.StatusBadRequest {
+			t.Errorf("unexpected start of automatic work status: %v", status)
+		}
+
+		status, started := doRequest(t, http.MethodPost, server.URL+"/work/manual/start", nil)
+		if status != http.StatusOK || len(started) != 1 || started[0].Status != "in_progress" {
+			t.Fatalf("unexpected start result: %v %v", status, started)
+		}
+		if status, _ := doRequest(t, http.MethodPut, server.URL+"/work/manual/cancel", nil); status != http.StatusConflict {
+			t.Errorf("unexpected cancel of started work status: %v", status)
+		}
+		move := &models.WorkItem{StartDate: now.Add(time.Hour)}
+		if status, _ := doRequest(t, http.MethodPut, server.URL+"/work/manual/move", move); status != http.StatusConflict {
+			t.Errorf("unexpected move of started work status: %v", status)
+		}
+
+		status, finished := doRequest(t, http.MethodPost, server.URL+"/work/manual/finish", nil)
+		if status != http.StatusOK || len(finished) != 1 || finished[0].Status != "completed" {
+			t.Fatalf("unexpected finish result: %v %v", status, finished)
+		}
+		if finished[0].DurationMinutes > 11 {
+			t.Errorf("unexpected duration after early finish: %v", finished[0].DurationMinutes)
+		}
+		if finished[0].ActualStart.IsZero() || finished[0].ActualEnd.Before(finished[0].ActualStart) {
+			t.Errorf("unexpected actual start and end: %v %v", finished[0].ActualStart, finished[0].ActualEnd)
+		}
+
+		query := url.Values{}
+		query.Set("fromDate", now.Add(-time.Hour).Format(time.RFC3339))
+		query.Set("toDate", now.Add(time.Hour).Format(time.RFC3339))
+		query.Set("groupBy", "workType")
+		resp, err := http.Get(server.URL + "/report?" + query.Encode())
+		if err != nil {
+			t.Fatalf("unable to get report: %v", err)
+		}
+		rows := []map[string]any{}
+		json.NewDecoder(resp.Body).Decode(&rows)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || len(rows) != 1 || rows[0]["key"] != "manual" || rows[0]["plannedMinutes"] != float64(120) {
+			t.Errorf("unexpected report: %v %v", resp.StatusCode, rows)
+		}
+	})
+
+	t.Run("succees report outcome with reason", func(t *testing.T) {
+		server, repo := newTestServer(t)
+
+		now := time.Now().UTC()
+		repo.Add(context.Background(), &models.WorkItem{
+			WorkId:          "automatic",
+			Zones:           []string{"Zone_1"},
+			StartDate:       now.Add(-10 * time.Minute),
+			DurationMinutes: 120,
+			Status:          "in_progress",
+			WorkType:        "automatic",
+		})
+
+		if status, _ := doRequest(t, http.MethodPost, server.URL+"/work/automatic/outcome", map[string]string{"status": "failed"}); status != http.StatusBadRequest {
+			t.Errorf("unexpected outcome without reason status: %v", status)
+		}
+
+		status, reported := doRequest(t, http.MethodPost, server.URL+"/work/automatic/outcome", map[string]string{"status": "rolled_back", "reason": "health check failed"})
+		if status != http.StatusOK || len(reported) != 1 || reported[0].Status != "rolled_back" || reported[0].Reason != "health check failed" {
+			t.Fatalf("unexpected outcome result: %v %v", status, reported)
+		}
+		if reported[0].DurationMinutes > 11 {
+			t.Errorf("unexpected duration after rollback: %v", reported[0].DurationMinutes)
+		}
+
+		query := url.Values{}
+		query.Set("fromDate", now.Add(-time.Hour).Format(time.RFC3339))
+		query.Set("toDate", now.Add(time.Hour).Format(time.RFC3339))
+		query.Set("statuses", "rolled_back")
+		status, listed := doRequest(t, http.MethodGet, server.URL+"/schedule?"+query.Encode(), nil)
+		if status != http.StatusOK || len(listed) != 1 || listed[0].WorkId != "automatic" {
+			t.Errorf("unexpected schedule of rolled back works: %v %v", status, listed)
+		}
+	})
+
+	t.Run("succees hold and confirm work", func(t *testing.T) {
+		server, _ := newTestServer(t)
+
+		tomorrow := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+		newWork := map[string]any{
+			"zones":           []string{"Zone_2", "Zone_4"},
+			"startDate":       tomorrow.Add(1 * time.Hour),
+			"durationMinutes": 60,
+			"deadline":        tomorrow.Add(5 * time.Hour),
+			"priority":        "regular",
+			"workType":        "manual",
+			"holdUntil":       tomorrow,
+		}
+		status, held := doRequest(t, http.MethodPost, server.URL+"/work", newWork)
+		if status != http.StatusOK || len(held) != 1 || held[0].Status != "held" || !held[0].HoldUntil.Equal(tomorrow) {
+			t.Fatalf("unexpected hold result: %v %v", status, held)
+		}
+
+		newWork["holdUntil"] = tomorrow.Add(2 * time.Hour)
+		if status, _ := doRequest(t, http.MethodPost, server.URL+"/work", newWork); status != http.StatusBadRequest {
+			t.Errorf("unexpected status of hold expiring after start: %v", status)
+		}
+
+		status, confirmed := doRequest(t, http.MethodPost, server.URL+"/work/"+held[0].WorkId+"/confirm", nil)
+		if status != http.StatusOK || len(confirmed) != 1 || confirmed[0].Status != "planned" {
+			t.Fatalf("unexpected confirm result: %v %v", status, confirmed)
+		}
+		doRequest(t, http.MethodPut, server.URL+"/work/"+held[0].WorkId+"/cancel", nil)
+		if status, _ := doRequest(t, http.MethodPost, server.URL+"/work/"+held[0].WorkId+"/confirm", nil); status != http.StatusConflict {
+			t.Errorf("unexpected status of canceled work confirm: %v", status)
+		}
+	})
+
+	t.Run("succees not found work", func(t *testing.T) {
+		server, _ := newTestServer(t)
+
+		if status, _ := doRequest(t, http.MethodGet, server.URL+"/work/missing", nil); status != http.StatusNotFound {
+			t.Errorf("unexpected get status: %v", status)
+		}
+		if status, _ := doRequest(t, http.MethodPut, server.URL+"/work/missing/cancel", nil); status != http.StatusNotFound {
+			t.Errorf("unexpected cancel status: %v", status)
+		}
+		if status, _ := doRequest(t, http.MethodPost, server.URL+"/work/missing/start", nil); status != http.StatusNotFound {
+			t.Errorf("unexpected start status: %v", status)
+		}
+	})
+
+	t.Run("succees list schedule by pages", func(t *testing.T) {
+		server, repo := newTestServer(t)
+
+		tomorrow := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+		for i := 0; i < 3; i++ {
+			repo.Add(context.Background(), &models.WorkItem{
+				WorkId:          fmt.Sprint(i),
+				Zones:           []string{"Zone_1"},
+				StartDate:       tomorrow.Add(time.Duration(i) * time.Hour),
+				DurationMinutes: 30,
+				Status:          "planned",
+			})
+		}
+
+		query := url.Values{}
+		query.Set("fromDate", tomorrow.Format(time.RFC3339))
+		query.Set("toDate", tomorrow.Add(24*time.Hour).Format(time.RFC3339))
+		query.Set("limit", "2")
+		query.Set("sortOrder", "desc")
+		query.Set("fields", "workId")
+
+		var workIds []string
+		for page := 0; page < 3; page++ {
+			resp, err := http.Get(server.URL + "/schedule?" + query.Encode())
+			if err != nil {
+				t.Fatalf("unable to get schedule: %v", err)
+			}
+			works := []map[string]any{}
+			json.NewDecoder(resp.Body).Decode(&works)
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected schedule status: %v", resp.StatusCode)
+			}
+			for _, work := range works {
+				if len(work) != 1 {
+					t.Errorf("unexpected projected work: %v", work)
+				}
+				workIds = append(workIds, fmt.Sprint(work["workId"]))
+			}
+			cursor := resp.Header.Get("X-Next-Cursor")
+			if cursor == "" {
+				break
+			}
+			query.Set("cursor", cursor)
+		}
+		if strings.Join(workIds, ",") != "2,1,0" {
+			t.Errorf("unexpected paged works order: %v", workIds)
+		}
+
+		query.Set("sortBy", "endDate")
+		status, _ := doRequest(t, http.MethodGet, server.URL+"/schedule?"+query.Encode(), nil)
+		if status != http.StatusBadRequest {
+			t.Errorf("unexpected status for cursor of another sort: %v", status)
+		}
+	})
+}
+
+func doConfigRequest(t *testing.T, method string, url string, body any, result any) int {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatalf("unable to encode request body: %v", err)
+		}
+	}
+	req, err := http.NewRequest(method, url, &reqBody)
+	if err != nil {
+		t.Fatalf("unable to create request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unable to do request %s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		t.Fatalf("unable to decode response of %s %s: %v", method, url, err)
+	}
+	return resp.StatusCode
+}
+
+func TestConfigApi(t *testing.T) {
+
+	t.Run("succees update config and roll it back", func(t *testing.T) {
+		server, _ := newTestServer(t)
+
+		current := ConfigDocument{}
+		status := doConfigRequest(t, http.MethodGet, server.URL+"/config", nil, &current)
+		if status != http.StatusOK || current.Version == nil || current.Document == nil {
+			t.Fatalf("unexpected current config: %v %+v", status, current)
+		}
+
+		invalid := ErrorStruct{}
+		status = doConfigRequest(t, http.MethodPut, server.URL+"/config", PutConfig{Document: "max_deadline_days: 0\n"}, &invalid)
+		if status != http.StatusBadRequest || invalid.ErrorCode != "Invalid config" || len(invalid.Issues) < 2 {
+			t.Errorf("unexpected invalid config result: %v %+v", status, invalid)
+		}
+
+		shorter := strings.Replace(*current.Document, "max_deadline_days: 28", "max_deadline_days: 14", 1)
+		updated := ConfigDocument{}
+		status = doConfigRequest(t, http.MethodPut, server.URL+"/config", PutConfig{Document: shorter}, &updated)
+		if status != http.StatusOK || *updated.Version != *current.Version+1 || updated.Revision == nil {
+			t.Fatalf("unexpected updated config: %v %+v", status, updated)
+		}
+
+		history := []map[string]any{}
+		if status = doConfigRequest(t, http.MethodGet, server.URL+"/config/history", nil, &history); status != http.StatusOK || len(history) != 1 {
+			t.Errorf("unexpected config history: %v %v", status, history)
+		}
+
+		// previous file content is applied again as a new revision
+		longer := strings.Replace(shorter, "max_deadline_days: 14", "max_deadline_days: 21", 1)
+		doConfigRequest(t, http.MethodPut, server.URL+"/config", PutConfig{Document: longer}, &ConfigDocument{})
+		rolledBack := ConfigDocument{}
+		status = doConfigRequest(t, http.MethodPost, server.URL+fmt.Sprintf("/config/history/%d/rollback", *updated.Revision), nil, &rolledBack)
+		if status != http.StatusOK || *rolledBack.Document != shorter || *rolledBack.Revision != *updated.Revision+2 {
+			t.Errorf("unexpected rolled back config: %v %+v", status, rolledBack)
+		}
+		if status = doConfigRequest(t, http.MethodPost, server.URL+"/config/history/100/rollback", nil, &ErrorStruct{}); status != http.StatusNotFound {
+			t.Errorf("unexpected status for unknown revision: %v", status)
+		}
+	})
+
+	t.Run("succees apply config when history is not saved", func(t *testing.T) {
+		server, _ := newTestServerWithHistory(t, failingHistory{})
+
+		current := ConfigDocument{}
+		doConfigRequest(t, http.MethodGet, server.URL+"/config", nil, &current)
+		shorter := strings.Replace(*current.Document, "max_deadline_days: 28", "max_deadline_days: 14", 1)
+		updated := ConfigDocument{}
+		status := doConfigRequest(t, http.MethodPut, server.URL+"/config", PutConfig{Document: shorter}, &updated)
+		if status != http.StatusOK || *updated.Version != *current.Version+1 || updated.Revision != nil {
+			t.Fatalf("unexpected updated config: %v %+v", status, updated)
+		}
+
+		applied := ConfigDocument{}
+		if status = doConfigRequest(t, http.MethodGet, server.URL+"/config", nil, &applied); status != http.StatusOK || *applied.Document != shorter {
+			t.Errorf("unexpected applied config: %v %+v", status, applied)
+		}
+	})
+}
+
+func TestZonesApi(t *testing.T) {
+
+	t.Run("succees add, change and decommission zone", func(t *testing.T) {
+		server, repo := newTestServer(t)
+
+		zone := zones.State{}
+		body := map[string]any{"windows": []map[string]any{{"startHour": 23, "endHour": 6}}, "pauseMinutes": 10}
+		status := doConfigRequest(t, http.MethodPut, server.URL+"/zones/Zone_5", body, &zone)
+		if status != http.StatusOK || !zone.Managed || zone.PauseMinutes != 10 || len(zone.Windows) != 2 {
+			t.Fatalf("unexpected added zone: %v %+v", status, zone)
+		}
+
+		invalid := ErrorStruct{}
+		body = map[string]any{"windows": []map[string]any{{"startHour": 30, "endHour": 6}}}
+		if status = doConfigRequest(t, http.MethodPut, server.URL+"/zones/Zone_5", body, &invalid); status != http.StatusBadRequest || len(invalid.Issues) == 0 {
+			t.Errorf("unexpected invalid zone result: %v %+v", status, invalid)
+		}
+
+		status = doConfigRequest(t, http.MethodPut, server.URL+"/zones/Zone_5", map[string]any{"blackListed": true}, &zone)
+		if status != http.StatusOK || !zone.BlackListed || len(zone.Windows) != 0 {
+			t.Errorf("unexpected black listed zone: %v %+v", status, zone)
+		}
+
+		// zone of config file with planned work
+		tomorrow := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+		repo.Add(context.Background(), &models.WorkItem{WorkId: "planned", Zones: []string{"Zone_1"}, StartDate: tomorrow, DurationMinutes: 60, Status: models.StatusPlanned})
+		if status = doConfigRequest(t, http.MethodDelete, server.URL+"/zones/Zone_1", nil, &ErrorStruct{}); status != http.StatusConflict {
+			t.Errorf("unexpected status for zone in use: %v", status)
+		}
+		status = doConfigRequest(t, http.MethodDelete, server.URL+"/zones/Zone_3", nil, &zone)
+		if status != http.StatusOK || !zone.Decommissioned {
+			t.Errorf("unexpected decommissioned zone: %v %+v", status, zone)
+		}
+
+		listed := []zones.State{}
+		doConfigRequest(t, http.MethodGet, server.URL+"/zones", nil, &listed)
+		ids := []string{}
+		for _, state := range listed {
+			if !state.Decommissioned && !state.BlackListed {
+				ids = append(ids, state.ZoneId)
+			}
+		}
+		if strings.Join(ids, ",") != "Zone_1,Zone_2,Zone_4" {
+			t.Errorf("unexpected white-listed zones: %v", ids)
+		}
+		if status = doConfigRequest(t, http.MethodGet, server.URL+"/zones/Zone_7", nil, &ErrorStruct{}); status != http.StatusNotFound {
+			t.Errorf("unexpected status for unknown zone: %v", status)
+		}
+	})
+}
+
+func TestProlongateWork(t *testing.T) {
+
+	prolongate := func(t *testing.T, status string) {
+		server, repo := newTestServer(t)
+
+		// whole day window, so prolongation doesn't depend on the test time
+		current := ConfigDocument{}
+		doConfigRequest(t, http.MethodGet, server.URL+"/config", nil, &current)
+		document := strings.Replace(*current.Document, "start_hour: 1 #", "start_hour: 0 #", 1)
+		document = strings.Replace(document, "end_hour: 5 #", "end_hour: 0 #", 1)
+		if status := doConfigRequest(t, http.MethodPut, server.URL+"/config", PutConfig{Document: document}, &ConfigDocument{}); status != http.StatusOK {
+			t.Fatalf("unexpected config update status: %v", status)
+		}
+
+		now := time.Now().UTC()
+		start := now.Truncate(5 * time.Minute).Add(-30 * time.Minute)
+		duration := int32(60)
+		if status == "overdue" {
+			duration = 30
+		}
+		repo.Add(context.Background(), &models.WorkItem{
+			WorkId:          "manual",
+			Zones:           []string{"Zone_1"},
+			StartDate:       start,
+			DurationMinutes: duration,
+			InitialDuration: duration,
+			Deadline:        now.Add(24 * time.Hour),
+			Status:          models.Status(status),
+			Priority:        "regular",
+			WorkType:        "manual",
+		})
+
+		code, prolongated := doRequest(t, http.MethodPut, server.URL+"/work/manual/prolongate", map[string]any{"durationMinutes": 90})
+		if code != http.StatusOK || len(prolongated) != 1 {
+			t.Fatalf("unexpected prolongate result: %v %v", code, prolongated)
+		}
+		work := prolongated[0]
+		if work.Status != "in_progress" || work.DurationMinutes != 90 || work.ProlongationCount != 1 || work.ProlongationMinutes != 90-duration {
+			t.Errorf("unexpected prolongated work: %+v", work)
+		}
+		if stored, _ := repo.GetById(context.Background(), "manual"); len(stored) != 1 || stored[0].ProlongationCount != 1 {
+			t.Errorf("unexpected stored work after prolongation: %v", stored)
+		}
+	}
+
+	t.Run("succees prolongate in progress work", func(t *testing.T) {
+		prolongate(t, "in_progress")
+	})
+
+	t.Run("succees prolongate overdue work", func(t *testing.T) {
+		prolongate(t, "overdue")
+	})
+}
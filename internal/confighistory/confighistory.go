@@ -0,0 +1,137 @@
+package confighistory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+)
+
+const interval = time.Minute
+
+// attempts to record config when other replicas save revisions at the same time
+const recordAttempts = 3
+
+// Recorder saves each applied config to history, so it can be rolled back later. Config is applied
+// by file edits and through API the same way, both are recorded. History is the source of truth
+// shared by replicas: revisions saved by other replicas are applied periodically, and on start
+// the latest revision replaces config file if they differ.
+type Recorder struct {
+	Repository repository.ConfigHistoryRepository
+	Config     *configuration.Configurator
+
+	mu sync.Mutex
+	// revision is the latest revision recorded or applied by the replica
+	revision int64
+}
+
+func NewRecorder(repo repository.ConfigHistoryRepository, config *configuration.Configurator) *Recorder {
+	return &Recorder{
+		Repository: repo,
+		Config:     config,
+	}
+}
+
+func (r *Recorder) Run(ctx context.Context) {
+	go r.process(ctx, r.Config.Subscribe())
+}
+
+func (r *Recorder) process(ctx context.Context, configs <-chan *configuration.Config) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case conf := <-configs:
+			if _, _, err := r.Record(ctx, conf); err != nil {
+				log.Printf("WARNING: Find error while saving config version %d to history, %s\n", conf.Version, err)
+			}
+		case <-ticker.C:
+			if err := r.Sync(ctx); err != nil {
+				log.Printf("WARNING: Find error while syncing config history, %s\n", err)
+			}
+		}
+	}
+}
+
+// Sync applies the latest revision if it is newer than the revision known to the replica, e.g.
+// saved by another replica or while the replica was down. Config file edits which are not
+// recorded yet are kept.
+func (r *Recorder) Sync(ctx context.Context) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revisions, err := r.Repository.ListConfigs(ctx)
+	if err != nil || len(revisions) == 0 || revisions[0].Revision <= r.revision {
+		return
+	}
+	latest := revisions[0]
+	if latest.Document != string(r.Config.Current().Document) {
+		conf, applyErr := r.Config.Apply([]byte(latest.Document))
+		if applyErr != nil {
+			return fmt.Errorf("config revision %d is not applied: %w", latest.Revision, applyErr)
+		}
+		log.Printf("successfully applied config revision %d as version %d\n", latest.Revision, conf.Version)
+	}
+	r.revision = latest.Revision
+	return
+}
+
+// Record saves config as the next revision unless it is the same as the latest one, which
+// happens on restart and when several replicas record the same config.
+func (r *Recorder) Record(ctx context.Context, conf *configuration.Config) (revision repository.ConfigRevision, recorded bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for attempt := 0; attempt < recordAttempts; attempt++ {
+		var revisions []repository.ConfigRevision
+		revisions, err = r.Repository.ListConfigs(ctx)
+		if err != nil {
+			return
+		}
+		var previous int64
+		if len(revisions) > 0 {
+			if revisions[0].Document == string(conf.Document) {
+				revision = revisions[0]
+				r.revision = revision.Revision
+				return
+			}
+			previous = revisions[0].Revision
+		}
+		revision, err = r.Repository.SaveConfig(ctx, string(conf.Document), previous)
+		if repository.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		recorded = true
+		r.revision = revision.Revision
+		log.Printf("successfully saved config version %d as revision %d\n", conf.Version, revision.Revision)
+		return
+	}
+	return
+}
+
+// Rollback applies document of the revision, it is recorded as a new revision. The applied config
+// is returned with zero revision if it is not recorded.
+func (r *Recorder) Rollback(ctx context.Context, revision int64) (conf *configuration.Config, saved repository.ConfigRevision, err error) {
+	previous, err := r.Repository.GetConfig(ctx, revision)
+	if err != nil {
+		return
+	}
+	conf, err = r.Config.Apply([]byte(previous.Document))
+	if err != nil {
+		return
+	}
+	saved, _, recordErr := r.Record(ctx, conf)
+	if recordErr != nil {
+		log.Printf("WARNING: Find error while saving rolled back config version %d to history, %s\n", conf.Version, recordErr)
+	}
+	return
+}
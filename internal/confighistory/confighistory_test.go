@@ -0,0 +1,94 @@
+package confighistory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"workScheduler/internal/configuration"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
+)
+
+const testConfigName = "../scheduler/test_configs/scheduler_pre_final_config.yml"
+
+func newReplica(t *testing.T, ctx context.Context, document string) *configuration.Configurator {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(document), 0644); err != nil {
+		t.Fatalf("unable to write config: %v", err)
+	}
+	c := configuration.NewConfigurator(ctx, path)
+	if err := c.Run(); err != nil {
+		t.Fatalf("unable to run configurator: %v", err)
+	}
+	return c
+}
+
+func TestReplicasFollowHistory(t *testing.T) {
+
+	t.Run("succees replicas follow the latest revision", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		document, err := os.ReadFile(testConfigName)
+		if err != nil {
+			t.Fatalf("unable to read config: %v", err)
+		}
+		withDeadline := func(days string) string {
+			return strings.Replace(string(document), "max_deadline_days: 28", "max_deadline_days: "+days, 1)
+		}
+		repo := inmemoryrepository.NewInmemoryRepository()
+
+		first := NewRecorder(repo, newReplica(t, ctx, withDeadline("21")))
+		if err := first.Sync(ctx); err != nil {
+			t.Fatalf("unexpected sync error: %v", err)
+		}
+		if _, recorded, err := first.Record(ctx, first.Config.Current()); err != nil || !recorded {
+			t.Fatalf("unexpected first record: %v %v", recorded, err)
+		}
+
+		// replica restarted with stale file gets the latest revision instead of recording the file
+		second := NewRecorder(repo, newReplica(t, ctx, withDeadline("14")))
+		if err := second.Sync(ctx); err != nil {
+			t.Fatalf("unexpected sync error: %v", err)
+		}
+		if second.Config.Current().MaxDeadlineDays != 21 {
+			t.Errorf("unexpected config of restarted replica: %v", second.Config.Current().MaxDeadlineDays)
+		}
+		if _, recorded, _ := second.Record(ctx, second.Config.Current()); recorded {
+			t.Errorf("config of restarted replica is recorded again")
+		}
+
+		conf, err := first.Config.Apply([]byte(withDeadline("7")))
+		if err != nil {
+			t.Fatalf("unexpected apply error: %v", err)
+		}
+		first.Record(ctx, conf)
+		if err := second.Sync(ctx); err != nil {
+			t.Fatalf("unexpected sync error: %v", err)
+		}
+		if second.Config.Current().MaxDeadlineDays != 7 {
+			t.Errorf("replica doesn't follow config applied by another one: %v", second.Config.Current().MaxDeadlineDays)
+		}
+
+		// both replicas record the same config at once
+		var wg sync.WaitGroup
+		for _, r := range []*Recorder{first, second} {
+			conf, err := r.Config.Apply([]byte(withDeadline("10")))
+			if err != nil {
+				t.Fatalf("unexpected apply error: %v", err)
+			}
+			wg.Add(1)
+			go func(r *Recorder, conf *configuration.Config) {
+				defer wg.Done()
+				if _, _, err := r.Record(ctx, conf); err != nil {
+					t.Errorf("unexpected record error: %v", err)
+				}
+			}(r, conf)
+		}
+		wg.Wait()
+		if revisions, _ := repo.ListConfigs(ctx); len(revisions) != 3 {
+			t.Errorf("unexpected revisions count: %v, want 3", len(revisions))
+		}
+	})
+}
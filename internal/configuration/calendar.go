@@ -0,0 +1,108 @@
+package configuration
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// AvailabilityRequirement is min_avialable_zones for the hours, weekdays and dates it matches, an
+// empty selector matches any time. Hours from start_hour to end_hour may cross midnight, equal
+// hours match the whole day. Dates are limited by effective_from and effective_until.
+type AvailabilityRequirement struct {
+	MinAvialableZones int32          `yaml:"min_avialable_zones"`
+	StartHour         uint32         `yaml:"start_hour"`
+	EndHour           uint32         `yaml:"end_hour"`
+	Weekdays          []string       `yaml:"weekdays"`
+	WeekdayValues     []time.Weekday `yaml:"-"`
+	Effective         `yaml:",inline"`
+}
+
+func (r *AvailabilityRequirement) matches(t time.Time) bool {
+	hour := uint32(t.Hour())
+	if r.StartHour < r.EndHour && (hour < r.StartHour || hour >= r.EndHour) {
+		return false
+	}
+	if r.StartHour > r.EndHour && hour < r.StartHour && hour >= r.EndHour {
+		return false
+	}
+	if len(r.WeekdayValues) > 0 && !slices.Contains(r.WeekdayValues, t.Weekday()) {
+		return false
+	}
+	return r.InEffect(t)
+}
+
+// minAvialableZonesAt returns the strictest calendar requirement matching t, min_avialable_zones
+// is used if no requirement matches.
+func (conf *Config) minAvialableZonesAt(t time.Time) (zones int32) {
+	matched := false
+	for _, r := range conf.MinAvialableZonesCalendar {
+		if r.matches(t) && (!matched || r.MinAvialableZones > zones) {
+			zones = r.MinAvialableZones
+			matched = true
+		}
+	}
+	if !matched {
+		zones = conf.MinAvialableZones
+	}
+	return
+}
+
+// MinAvialableZonesBetween returns the strictest requirement during interval, the calendar
+// changes only on hour boundaries and when requirements come into effect, so entries effective
+// from the middle of the interval are checked for the time they cover.
+func (conf *Config) MinAvialableZonesBetween(from time.Time, to time.Time) (zones int32) {
+	zones = conf.minAvialableZonesAt(from)
+	check := func(t time.Time) {
+		if z := conf.minAvialableZonesAt(t); z > zones {
+			zones = z
+		}
+	}
+	for t := from.Truncate(time.Hour).Add(time.Hour); t.Before(to); t = t.Add(time.Hour) {
+		check(t)
+	}
+	for _, r := range conf.MinAvialableZonesCalendar {
+		if r.From.After(from) && r.From.Before(to) {
+			check(r.From)
+		}
+	}
+	return
+}
+
+func parseWeekday(name string) (weekday time.Weekday, ok bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), name) {
+			return d, true
+		}
+	}
+	return
+}
+
+func (l *linter) lintCalendar(conf *Config) {
+	maxZones := int32(len(conf.WhiteList) - 2)
+	for i := range conf.MinAvialableZonesCalendar {
+		r := &conf.MinAvialableZonesCalendar[i]
+		if r.MinAvialableZones > maxZones {
+			l.errorf(path("min_avialable_zones_calendar", i, "min_avialable_zones"), "min_avialable_zones must not be greater then zone count=%v, got %v", maxZones, r.MinAvialableZones)
+		}
+		if r.MinAvialableZones < 0 {
+			l.errorf(path("min_avialable_zones_calendar", i, "min_avialable_zones"), "min_avialable_zones must not be negative, got %v", r.MinAvialableZones)
+		}
+		if r.StartHour >= 24 {
+			l.errorf(path("min_avialable_zones_calendar", i, "start_hour"), "start_hour must be uint in range from 0 to 23, got %d", r.StartHour)
+		}
+		if r.EndHour >= 24 {
+			l.errorf(path("min_avialable_zones_calendar", i, "end_hour"), "end_hour must be uint in range from 0 to 23, got %d", r.EndHour)
+		}
+		r.WeekdayValues = nil
+		for j, name := range r.Weekdays {
+			if weekday, ok := parseWeekday(name); ok {
+				r.WeekdayValues = append(r.WeekdayValues, weekday)
+			} else {
+				l.errorf(path("min_avialable_zones_calendar", i, "weekdays", j), "unknown weekday %s, expected monday..sunday", name)
+			}
+		}
+		l.lintEffective(&r.Effective, "min_avialable_zones_calendar", i)
+	}
+}
@@ -1,33 +1,44 @@
 package configuration
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
-	"regexp"
-	"sort"
-	"strconv"
 	"sync"
-	"time"
+	"sync/atomic"
 
 	"github.com/fsnotify/fsnotify"
 	"gopkg.in/yaml.v3"
 )
 
+// Configurator keeps the latest valid config as an immutable snapshot, components read it with
+// Current or receive each new version by Subscribe.
 type Configurator struct {
 	ConfigPath string
-	Data       *Config
 	Ctx        context.Context
 	Mu         *sync.Mutex
 	Started    bool
+
+	current     atomic.Pointer[Config]
+	subscribers []chan *Config
+	// zones managed through API are merged over config file
+	zones []Zone
 }
 
 type Config struct {
-	WhiteList               map[string][]Window  `yaml:"white_list"`
-	BlackList               []string             `yaml:"black_list"`
+	// Version is incremented on each applied config
+	Version int64 `yaml:"-"`
+	// Document is the source the config was read from
+	Document  []byte              `yaml:"-"`
+	WhiteList map[string][]Window `yaml:"white_list"`
+	// BlackList depends on time, it is resolved from entries by At and is set directly only in
+	// configs built in code
+	BlackList               []string             `yaml:"-"`
+	BlackListEntries        []BlackListEntry     `yaml:"black_list"`
 	MinAvialableZones       int32                `yaml:"min_avialable_zones"`
 	PausesMinutes           map[string]int32     `yaml:"pauses"`
 	MinWorkDurationMinutes  WorkDurationSettings `yaml:"min_work_duration_minutes"`
@@ -35,11 +46,22 @@ type Config struct {
 	MaxDeadlineDays         int32                `yaml:"max_deadline_days"`
 	TimeCompressionPercents string               `yaml:"time_compression_percents"`
 	TimeCompressionRate     float32
+	RetentionDays           map[string]int32 `yaml:"retention_days"`
+	// LimitsOverrides replace duration, compression and deadline limits per zone, work type and priority
+	LimitsOverrides []LimitsOverride `yaml:"limits_overrides"`
+	// WhiteListOverrides replace white-list windows of zones per work type and priority
+	WhiteListOverrides []WindowsOverride `yaml:"white_list_overrides"`
+	// MinAvialableZonesCalendar replaces min_avialable_zones by hours, weekdays and dates
+	MinAvialableZonesCalendar []AvailabilityRequirement `yaml:"min_avialable_zones_calendar"`
 }
 
+// RetentionStatuses are final work statuses which may be archived by retention.
+var RetentionStatuses = []string{"completed", "canceled", "failed", "rolled_back", "aborted"}
+
 type Window struct {
-	StartHour uint32 `yaml:"start_hour"`
-	EndHour   uint32 `yaml:"end_hour"`
+	StartHour uint32 `yaml:"start_hour" bson:"startHour" json:"startHour"`
+	EndHour   uint32 `yaml:"end_hour" bson:"endHour" json:"endHour"`
+	Effective `yaml:",inline" bson:",inline"`
 }
 
 type WorkDurationSettings struct {
@@ -50,16 +72,64 @@ type WorkDurationSettings struct {
 func NewConfigurator(ctx context.Context, filepath string) *Configurator {
 	return &Configurator{
 		ConfigPath: filepath,
-		Data:       &Config{},
 		Ctx:        ctx,
 		Mu:         &sync.Mutex{},
 		Started:    false,
 	}
 }
 
-func (c *Configurator) Run() {
-	c.updateConfig()
+// NewStaticConfigurator returns configurator with the given config which is not read from file.
+func NewStaticConfigurator(conf *Config) *Configurator {
+	c := NewConfigurator(context.Background(), "")
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	c.publish(conf)
+	c.Started = true
+	return c
+}
+
+// Current returns the latest applied config, it is shared and must not be modified.
+func (c *Configurator) Current() *Config {
+	if conf := c.current.Load(); conf != nil {
+		return conf
+	}
+	return &Config{}
+}
+
+// Subscribe returns channel receiving the current and each next applied config, a subscriber
+// which is late to receive gets only the latest version.
+func (c *Configurator) Subscribe() <-chan *Config {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	ch := make(chan *Config, 1)
+	if conf := c.current.Load(); conf != nil {
+		ch <- conf
+	}
+	c.subscribers = append(c.subscribers, ch)
+	return ch
+}
+
+// publish applies config as the next version, Mu must be held.
+func (c *Configurator) publish(conf *Config) {
+	conf.Version = c.Current().Version + 1
+	c.current.Store(conf)
+	for _, ch := range c.subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- conf
+	}
+}
+
+// Run loads config file and watches it, an invalid config file is an error only on start, later
+// invalid edits are ignored.
+func (c *Configurator) Run() error {
+	if err := c.updateConfig(); err != nil {
+		return err
+	}
 	go c.mainProcess()
+	return nil
 }
 
 func (c *Configurator) mainProcess() {
@@ -97,125 +167,118 @@ func (c *Configurator) mainProcess() {
 	}
 }
 
-func (c *Configurator) updateConfig() {
+func (c *Configurator) updateConfig() (err error) {
 	log.Println("Config processing...")
 	c.Mu.Lock()
 	defer c.Mu.Unlock()
 	conf, err := c.readConfig()
-	if err != nil && !c.Started {
-		log.Fatal(err)
-	} else if err != nil {
-		log.Printf("WARNING: Given config is invalid, config update ignoring: %s", err)
-		return
-	}
-	err = c.validateConfig(&conf)
-	if err != nil && !c.Started {
-		log.Fatal(err)
-	} else if err != nil {
-		log.Printf("WARNING: Given config is invalid, config update ignoring: %s", err)
-		return
-	} else {
-		c.Data = &conf
-		c.Started = true
-		log.Println("Configuration updated Successfuly!")
-	}
-}
-
-func (c *Configurator) readConfig() (config Config, err error) {
-	file, err := os.ReadFile(c.ConfigPath)
 	if err != nil {
-		return
+		return c.rejectConfig(err)
 	}
-	config.WhiteList = make(map[string][]Window)
-	config.PausesMinutes = make(map[string]int32)
-	err = yaml.Unmarshal(file, &config)
-	if err != nil {
+	if c.Started && bytes.Equal(conf.Document, c.Current().Document) {
+		// file is rewritten by Apply or written by editor several times
+		log.Println("Config is not changed, config update ignoring")
 		return
 	}
-
+	mergeZones(&conf, c.zones)
+	if err = c.validateConfig(&conf); err != nil {
+		return c.rejectConfig(err)
+	}
+	c.publish(&conf)
+	c.Started = true
+	log.Printf("Configuration updated Successfuly! Version %d\n", conf.Version)
 	return
 }
 
-func (c *Configurator) validateConfig(conf *Config) error {
-	errStr := ""
-	ts := time.Now()
-	zones := make(map[string]time.Time)
-	for name, zone := range conf.WhiteList {
-		zones[name] = ts
-		additionalIntervals := []Window{}
-		for i, interval := range zone {
-			if interval.StartHour >= 24 {
-				errStr += "start_hour must be uint in range from 0 to 23; "
-			}
-			if interval.EndHour >= 24 {
-				errStr += "EndHour must be uint in range from 1 to 23; "
-			}
-			if interval.StartHour >= interval.EndHour {
-				// add new interval above 00:00
-				additionalIntervals = append(additionalIntervals, Window{StartHour: 0, EndHour: interval.EndHour})
-				conf.WhiteList[name][i].EndHour = 24
-			}
+// rejectConfig logs every issue of invalid config, the error is returned only before the first
+// config is applied.
+func (c *Configurator) rejectConfig(err error) error {
+	var invalid *ValidationError
+	if errors.As(err, &invalid) {
+		for _, issue := range invalid.Issues {
+			log.Printf("WARNING: config %s %s: %s\n", c.ConfigPath, issue.Severity, issue)
 		}
-		conf.WhiteList[name] = append(zone, additionalIntervals...)
 	}
-	for _, name := range conf.BlackList {
-		if _, ok := zones[name]; ok {
-			errStr += "Zone from black list in white list; "
-		} else {
-			zones[name] = ts
-		}
-	}
-	// sort black list alphabeticaly
-	sort.Slice(conf.BlackList, func(i, j int) bool {
-		return conf.BlackList[i] < conf.BlackList[j]
-	})
-	if conf.MinAvialableZones > int32(len(conf.WhiteList)-2) { //|| conf.MinAvialableZones < 2
-		errStr += fmt.Sprintf("MinAvialableZones must not be greater then zone count=%v, got MinAvialableZones=%v; ", int32(len(conf.WhiteList)-2), conf.MinAvialableZones)
+	if !c.Started {
+		return fmt.Errorf("config %s is invalid: %w", c.ConfigPath, err)
 	}
+	log.Printf("WARNING: Given config is invalid, config update ignoring: %s", err)
+	return nil
+}
 
-	for k, v := range conf.PausesMinutes {
-		if _, ok := zones[k]; !ok {
-			errStr += fmt.Sprintf("%s zone in pauses not found in zone (black/white list); ", k)
-		}
-		if v < 0 || v > 60 {
-			errStr += "Pauses value must be in range from 0 to 60"
-		}
+// Apply validates config document and applies it the same way as edits of config file, the
+// document is written to config file, so it is kept after restart.
+func (c *Configurator) Apply(document []byte) (conf *Config, err error) {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	config, err := parseConfig(document)
+	if err != nil {
+		return
 	}
-
-	if conf.MinWorkDurationMinutes.Automatic < 5 {
-		errStr += "min_work_duration_minutes duration value for automatic works must be greater then 5; "
+	mergeZones(&config, c.zones)
+	if err = c.validateConfig(&config); err != nil {
+		return
 	}
-
-	if conf.MinWorkDurationMinutes.Manual < 30 {
-		errStr += "min_work_duration_minutes duration value for manual works must be greater then 30;"
+	if c.ConfigPath != "" {
+		if err = os.WriteFile(c.ConfigPath, document, 0644); err != nil {
+			return
+		}
 	}
+	c.publish(&config)
+	c.Started = true
+	log.Printf("Configuration updated Successfuly! Version %d\n", config.Version)
+	conf = &config
+	return
+}
 
-	if conf.MinWorkDurationMinutes.Automatic > conf.MaxWorkDurationMinutes.Automatic {
-		errStr += "min_work_duration_minutes duration value for automatic can't be greater then max_work_duration_minutes;"
+// LoadFile reads config file, merges zones managed through API over it and validates the result
+// without applying it.
+func LoadFile(path string, zones []Zone) (conf *Config, err error) {
+	c := NewConfigurator(context.Background(), path)
+	config, err := c.readConfig()
+	if err != nil {
+		return
+	}
+	mergeZones(&config, zones)
+	if err = c.validateConfig(&config); err != nil {
+		return
 	}
+	conf = &config
+	return
+}
 
-	if conf.MinWorkDurationMinutes.Manual > conf.MaxWorkDurationMinutes.Manual {
-		errStr += "min_work_duration_minutes duration value for manual can't be greater then max_work_duration_minutes;"
+func (c *Configurator) readConfig() (config Config, err error) {
+	file, err := os.ReadFile(c.ConfigPath)
+	if err != nil {
+		return
 	}
+	return parseConfig(file)
+}
 
-	if conf.MaxDeadlineDays <= 0 {
-		errStr += "max_deadline_days duration value must be greater then 0;"
+func parseConfig(document []byte) (config Config, err error) {
+	config.WhiteList = make(map[string][]Window)
+	config.PausesMinutes = make(map[string]int32)
+	err = yaml.Unmarshal(document, &config)
+	if err != nil {
+		err = parseError(err)
+		return
 	}
+	config.Document = document
+	return
+}
 
-	var validTimeCompressionPercents = regexp.MustCompile(`^(?P<num>[0-9]{1,2})%$`)
-	if len(conf.TimeCompressionPercents) != 0 {
-		if matches := validTimeCompressionPercents.FindStringSubmatch(conf.TimeCompressionPercents); len(matches) > 0 {
-			subgroup := validTimeCompressionPercents.SubexpIndex("num")
-			numValue, _ := strconv.ParseFloat(matches[subgroup], 32)
-			conf.TimeCompressionRate = 1 - (float32(numValue) / float32(100))
-		} else {
-			errStr += fmt.Sprintf("invalid time_compression_persents value in config: [%s]", conf.TimeCompressionPercents)
+// validateConfig normalizes config and returns ValidationError if it has errors, warnings are
+// only logged.
+func (c *Configurator) validateConfig(conf *Config) error {
+	issues := lintConfig(conf)
+	for _, issue := range issues {
+		if issue.Severity == SeverityWarning {
+			log.Printf("WARNING: config %s\n", issue)
 		}
 	}
-
-	if errStr != "" {
-		return errors.New(errStr)
-	} else {
-		return nil
+	invalid := &ValidationError{Issues: issues}
+	if invalid.HasErrors() {
+		return invalid
 	}
+	return nil
 }
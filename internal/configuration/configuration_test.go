@@ -2,8 +2,13 @@ package configuration
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
+
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -17,10 +22,387 @@ func TestConfigSuccees(t *testing.T) {
 		configurator := NewConfigurator(ctx, unitTestConfigName)
 		configurator.Run()
 		time.Sleep(2 * time.Second)
-		if configurator.Data.TimeCompressionRate != 0.90 {
-			t.Errorf("wrong TimeCompressionRate in config file %s: want 0.60, got %v", unitTestConfigName, configurator.Data.TimeCompressionRate)
+		if configurator.Current().TimeCompressionRate != 0.90 {
+			t.Errorf("wrong TimeCompressionRate in config file %s: want 0.60, got %v", unitTestConfigName, configurator.Current().TimeCompressionRate)
 		}
 		//todo check other fields automaitically
 	})
 
 }
+
+func TestConfigVersions(t *testing.T) {
+
+	t.Run("succees subscribe to config versions", func(t *testing.T) {
+		configurator := NewStaticConfigurator(&Config{MaxDeadlineDays: 7})
+		updates := configurator.Subscribe()
+		if first := <-updates; first.Version != 1 || first.MaxDeadlineDays != 7 {
+			t.Errorf("unexpected first config: %+v", first)
+		}
+
+		configurator.Mu.Lock()
+		configurator.publish(&Config{MaxDeadlineDays: 14})
+		configurator.publish(&Config{MaxDeadlineDays: 21})
+		configurator.Mu.Unlock()
+		// late subscriber receives only the latest version
+		if latest := <-updates; latest.Version != 3 || latest.MaxDeadlineDays != 21 {
+			t.Errorf("unexpected latest config: %+v", latest)
+		}
+		if current := configurator.Current(); current.Version != 3 {
+			t.Errorf("unexpected current config version: %v, want 3", current.Version)
+		}
+	})
+}
+
+func TestLintConfig(t *testing.T) {
+
+	t.Run("succees report all issues with lines", func(t *testing.T) {
+		document := []byte(`white_list:
+  zone1:
+    - start_hour: 6
+      end_hour: 25
+black_list:
+  - zone1
+min_avialable_zones: 0
+min_work_duration_minutes:
+  automatic: 5
+  manual: 30
+max_work_duration_minutes:
+  automatic: 360
+  manual: 360
+max_deadline_days: 28
+time_compression_persents: 90%
+`)
+		_, err := NewStaticConfigurator(&Config{}).Apply(document)
+		invalid, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []Issue{
+			{Path: "white_list.zone1[0].end_hour", Severity: SeverityError, Line: 4},
+			{Path: "black_list[0]", Severity: SeverityError, Line: 6},
+			{Path: "min_avialable_zones", Severity: SeverityError, Line: 7},
+			{Path: "time_compression_persents", Severity: SeverityWarning, Line: 15},
+		}
+		if len(invalid.Issues) != len(want) {
+			t.Fatalf("unexpected issues: %+v", invalid.Issues)
+		}
+		for i, issue := range invalid.Issues {
+			if issue.Path != want[i].Path || issue.Severity != want[i].Severity || issue.Line != want[i].Line {
+				t.Errorf("unexpected issue %d: %+v, want %+v", i, issue, want[i])
+			}
+		}
+	})
+
+	t.Run("succees warn about unknown nested keys", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "config.yml")
+		document := []byte(`white_list:
+  zone1:
+    - start_hour: 6
+      end_hours: 20
+black_list:
+  - zone: zone2
+    effective_form: 2024-04-18
+`)
+		if err := os.WriteFile(file, document, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		all, err := Lint(file)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// document lacks required values, only warnings are checked
+		issues := []Issue{}
+		for _, issue := range all {
+			if issue.Severity == SeverityWarning {
+				issues = append(issues, issue)
+			}
+		}
+		want := []Issue{
+			{Path: "white_list.zone1[0].end_hours", Severity: SeverityWarning, Line: 4},
+			{Path: "black_list[0].effective_form", Severity: SeverityWarning, Line: 7},
+		}
+		if len(issues) != len(want) {
+			t.Fatalf("unexpected issues: %+v", issues)
+		}
+		for i, issue := range issues {
+			if issue.Path != want[i].Path || issue.Severity != want[i].Severity || issue.Line != want[i].Line {
+				t.Errorf("unexpected issue %d: %+v, want %+v", i, issue, want[i])
+			}
+		}
+	})
+
+	t.Run("succees report yaml error once", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "config.yml")
+		if err := os.WriteFile(file, []byte("white_list:\n  - : [\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		issues, err := Lint(file)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(issues) != 1 || issues[0].Line != 1 || issues[0].Message != "did not find expected key" {
+			t.Fatalf("unexpected issues: %+v", issues)
+		}
+	})
+}
+
+func TestLoadFile(t *testing.T) {
+
+	t.Run("succees merge managed zones over file", func(t *testing.T) {
+		managed := []Zone{
+			{ZoneId: "zone2", Windows: []Window{{StartHour: 20, EndHour: 23}}},
+			{ZoneId: "zone4", Decommissioned: true},
+		}
+		conf, err := LoadFile(unitTestConfigName, managed)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		view := conf.At(time.Now())
+		if slices.Contains(view.BlackList, "zone2") || len(view.WhiteList["zone2"]) == 0 || len(view.WhiteList["zone4"]) != 0 {
+			t.Errorf("unexpected merged zones: %v %v", view.BlackList, view.WhiteList)
+		}
+	})
+
+	t.Run("succees reject file with invalid managed zone", func(t *testing.T) {
+		if _, err := LoadFile(unitTestConfigName, []Zone{{ZoneId: "zone2", Windows: []Window{{StartHour: 30, EndHour: 6}}}}); err == nil {
+			t.Errorf("invalid managed zone is accepted")
+		}
+	})
+}
+
+func TestLimits(t *testing.T) {
+
+	t.Run("succees override limits per zone, work type and priority", func(t *testing.T) {
+		document := []byte(`white_list:
+  storage:
+    - start_hour: 0
+      end_hour: 23
+  network:
+    - start_hour: 0
+      end_hour: 23
+min_work_duration_minutes:
+  automatic: 5
+  manual: 30
+max_work_duration_minutes:
+  automatic: 360
+  manual: 360
+max_deadline_days: 28
+time_compression_percents: 20%
+limits_overrides:
+  - zones: [storage]
+    max_work_duration_minutes: 720
+    time_compression_percents: 50%
+  - zones: [storage]
+    work_type: manual
+    min_work_duration_minutes: 60
+  - priority: critical
+    max_deadline_days: 7
+`)
+		conf, err := NewStaticConfigurator(&Config{}).Apply(document)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if l := conf.Limits([]string{"storage"}, "automatic", "regular"); l.MinWorkDurationMinutes != 5 || l.MaxWorkDurationMinutes != 720 || l.MaxDeadlineDays != 28 || l.CompressionRate != 0.5 {
+			t.Errorf("unexpected storage automatic limits: %+v", l)
+		}
+		if l := conf.Limits([]string{"storage"}, "manual", "critical"); l.MinWorkDurationMinutes != 60 || l.MaxDeadlineDays != 7 || l.CompressionRate != 1 {
+			t.Errorf("unexpected storage critical limits: %+v", l)
+		}
+		// the strictest limits of all work zones are used
+		if l := conf.Limits([]string{"network", "storage"}, "automatic", "regular"); l.MaxWorkDurationMinutes != 360 || l.CompressionRate != 0.8 {
+			t.Errorf("unexpected limits of several zones: %+v", l)
+		}
+		if conf.LongestWorkMinutes() != 720 || conf.LongestDeadlineDays() != 28 {
+			t.Errorf("unexpected longest limits: %v %v", conf.LongestWorkMinutes(), conf.LongestDeadlineDays())
+		}
+	})
+}
+
+func TestWindows(t *testing.T) {
+
+	t.Run("succees override windows per work type and priority", func(t *testing.T) {
+		document := []byte(`white_list:
+  zone1:
+    - start_hour: 6
+      end_hour: 18
+  zone2:
+    - start_hour: 6
+      end_hour: 18
+min_work_duration_minutes:
+  automatic: 5
+  manual: 30
+max_work_duration_minutes:
+  automatic: 360
+  manual: 360
+max_deadline_days: 28
+white_list_overrides:
+  - zones: [zone1]
+    work_type: automatic
+    windows:
+      - start_hour: 22
+        end_hour: 6
+  - work_type: manual
+    priority: critical
+    windows:
+      - start_hour: 0
+        end_hour: 23
+`)
+		conf, err := NewStaticConfigurator(&Config{}).Apply(document)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, c := range []struct {
+			zone, workType, priority string
+			want                     []Window
+		}{
+			{"zone1", "automatic", "regular", []Window{{StartHour: 22, EndHour: 24}, {StartHour: 0, EndHour: 6}}},
+			{"zone1", "manual", "regular", []Window{{StartHour: 6, EndHour: 18}}},
+			{"zone2", "automatic", "regular", []Window{{StartHour: 6, EndHour: 18}}},
+			{"zone2", "manual", "critical", []Window{{StartHour: 0, EndHour: 23}}},
+		} {
+			if windows, ok := conf.Windows(c.zone, c.workType, c.priority); !ok || !reflect.DeepEqual(windows, c.want) {
+				t.Errorf("unexpected windows of %s %s %s work: %v", c.zone, c.priority, c.workType, windows)
+			}
+		}
+		if _, ok := conf.Windows("zone3", "manual", "regular"); ok {
+			t.Errorf("unexpected windows of zone not in white list")
+		}
+	})
+}
+
+func TestMinAvialableZonesCalendar(t *testing.T) {
+
+	t.Run("succees use the strictest requirement during interval", func(t *testing.T) {
+		document := []byte(`white_list:
+  zone1: []
+  zone2: []
+  zone3: []
+  zone4: []
+  zone5: []
+min_avialable_zones: 2
+min_avialable_zones_calendar:
+  - min_avialable_zones: 3
+    start_hour: 9
+    end_hour: 21
+    weekdays: [monday, tuesday, wednesday, thursday, friday]
+  - min_avialable_zones: 1
+    start_hour: 22
+    end_hour: 6
+  - min_avialable_zones: 3
+    effective_from: 2026-12-31
+    effective_until: 2027-01-01
+  - min_avialable_zones: 3
+    effective_from: 2026-10-25T10:30:00Z
+    effective_until: 2026-10-25T10:45:00Z
+min_work_duration_minutes:
+  automatic: 5
+  manual: 30
+max_work_duration_minutes:
+  automatic: 360
+  manual: 360
+max_deadline_days: 28
+`)
+		conf, err := NewStaticConfigurator(&Config{}).Apply(document)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// 2026-10-19 is monday
+		at := func(day int, hour int, minute int) time.Time {
+			return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+		}
+		for _, c := range []struct {
+			from, to time.Time
+			want     int32
+		}{
+			{at(19, 23, 0), at(20, 5, 0), 1},
+			{at(19, 6, 0), at(19, 8, 0), 2},
+			{at(19, 8, 30), at(19, 9, 10), 3},
+			{at(24, 10, 0), at(24, 12, 0), 2},
+			{at(19, 20, 0), at(19, 23, 0), 3},
+			{time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 1, 0, 0, 0, time.UTC), 3},
+			{time.Date(2027, 1, 1, 2, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 3, 0, 0, 0, time.UTC), 1},
+			// work crosses effective_from of the requirement, it is checked for the rest of the work
+			{time.Date(2026, 12, 30, 23, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 1, 0, 0, 0, time.UTC), 3},
+			{at(25, 10, 0), at(25, 11, 0), 3},
+			{at(25, 10, 50), at(25, 11, 30), 2},
+		} {
+			// scheduler checks works against config in effect at their start
+			if got := conf.At(c.from).MinAvialableZonesBetween(c.from, c.to); got != c.want {
+				t.Errorf("unexpected min_avialable_zones from %v to %v: %v, want %v", c.from, c.to, got, c.want)
+			}
+		}
+	})
+}
+
+func TestEffectiveDates(t *testing.T) {
+
+	t.Run("succees apply entries in effect at work time", func(t *testing.T) {
+		document := []byte(`white_list:
+  zone1:
+    - start_hour: 6
+      end_hour: 18
+      effective_until: 2026-11-27
+    - start_hour: 0
+      end_hour: 23
+      effective_from: 2026-11-27
+      effective_until: 2026-11-30
+    - start_hour: 6
+      end_hour: 18
+      effective_from: 2026-11-30
+  zone2:
+    - start_hour: 6
+      end_hour: 18
+  zone3:
+    - start_hour: 6
+      end_hour: 18
+black_list:
+  - zone4
+  - zone: zone2
+    effective_from: 2026-11-15
+min_work_duration_minutes:
+  automatic: 5
+  manual: 30
+max_work_duration_minutes:
+  automatic: 360
+  manual: 360
+max_deadline_days: 28
+`)
+		conf, err := NewStaticConfigurator(&Config{}).Apply(document)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		now := time.Date(2026, 11, 1, 10, 0, 0, 0, time.UTC)
+		if view := conf.At(now); !reflect.DeepEqual(view.BlackList, []string{"zone4"}) || len(view.WhiteList["zone1"]) != 1 || view.WhiteList["zone1"][0].EndHour != 18 {
+			t.Errorf("unexpected config now: %v %v", view.BlackList, view.WhiteList["zone1"])
+		}
+		sale := time.Date(2026, 11, 28, 10, 0, 0, 0, time.UTC)
+		if view := conf.At(sale); !reflect.DeepEqual(view.BlackList, []string{"zone2", "zone4"}) || len(view.WhiteList["zone1"]) != 1 || view.WhiteList["zone1"][0].EndHour != 23 {
+			t.Errorf("unexpected config during sale: %v %v", view.BlackList, view.WhiteList["zone1"])
+		}
+	})
+
+	t.Run("succees report invalid effective dates", func(t *testing.T) {
+		document := []byte(`white_list:
+  zone1:
+    - start_hour: 6
+      end_hour: 18
+      effective_from: 2026-12-01
+      effective_until: 2026-11-01
+black_list:
+  - zone: zone2
+    effective_from: tomorrow
+`)
+		_, err := NewStaticConfigurator(&Config{}).Apply(document)
+		invalid, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		paths := []string{}
+		for _, issue := range invalid.Issues {
+			paths = append(paths, issue.Path)
+		}
+		if !slices.Contains(paths, "white_list.zone1[0].effective_until") || !slices.Contains(paths, "black_list[0].effective_from") {
+			t.Errorf("unexpected issues: %+v", invalid.Issues)
+		}
+	})
+}
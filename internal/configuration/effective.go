@@ -0,0 +1,119 @@
+package configuration
+
+import (
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const dateLayout = "2006-01-02"
+
+// Effective limits time when config entry is in effect, effective_from is inclusive and
+// effective_until is exclusive. Dates are RFC3339 times or days like 2024-04-18 in UTC.
+type Effective struct {
+	EffectiveFrom  string    `yaml:"effective_from" bson:"effectiveFrom,omitempty" json:"effectiveFrom,omitempty"`
+	EffectiveUntil string    `yaml:"effective_until" bson:"effectiveUntil,omitempty" json:"effectiveUntil,omitempty"`
+	From           time.Time `yaml:"-" bson:"-" json:"-"`
+	Until          time.Time `yaml:"-" bson:"-" json:"-"`
+}
+
+// InEffect reports whether entry is in effect at t, entries without dates are always in effect.
+func (e *Effective) InEffect(t time.Time) bool {
+	return (e.From.IsZero() || !t.Before(e.From)) && (e.Until.IsZero() || t.Before(e.Until))
+}
+
+// BlackListEntry is a zone from black list, it is either zone id or a mapping with zone and
+// effective dates.
+type BlackListEntry struct {
+	Zone      string `yaml:"zone"`
+	Effective `yaml:",inline"`
+}
+
+func (e *BlackListEntry) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		e.Zone = value.Value
+		return nil
+	}
+	type plain BlackListEntry
+	return value.Decode((*plain)(e))
+}
+
+// At returns config with entries in effect at t, it is used to check works planned in the future
+// against rules which will be in effect then. Calendar entries are kept, they are checked over
+// the whole work interval by MinAvialableZonesBetween.
+func (conf *Config) At(t time.Time) *Config {
+	view := *conf
+	// static configs built in code have no entries
+	if conf.BlackListEntries != nil {
+		view.BlackList = blackListAt(conf.BlackListEntries, t)
+	}
+	view.WhiteList = make(map[string][]Window, len(conf.WhiteList))
+	for zone, windows := range conf.WhiteList {
+		view.WhiteList[zone] = windowsAt(windows, t)
+	}
+	view.LimitsOverrides = nil
+	for _, o := range conf.LimitsOverrides {
+		if o.InEffect(t) {
+			view.LimitsOverrides = append(view.LimitsOverrides, o)
+		}
+	}
+	view.WhiteListOverrides = nil
+	for _, o := range conf.WhiteListOverrides {
+		if o.InEffect(t) {
+			o.Windows = windowsAt(o.Windows, t)
+			view.WhiteListOverrides = append(view.WhiteListOverrides, o)
+		}
+	}
+	return &view
+}
+
+func blackListAt(entries []BlackListEntry, t time.Time) (zones []string) {
+	zones = []string{}
+	for _, e := range entries {
+		if e.InEffect(t) {
+			zones = append(zones, e.Zone)
+		}
+	}
+	// sort black list alphabeticaly
+	sort.Strings(zones)
+	return
+}
+
+func windowsAt(windows []Window, t time.Time) (inEffect []Window) {
+	inEffect = []Window{}
+	for _, w := range windows {
+		if w.InEffect(t) {
+			inEffect = append(inEffect, w)
+		}
+	}
+	return
+}
+
+func parseEffectiveDate(value string) (date time.Time, err error) {
+	if date, err = time.Parse(time.RFC3339, value); err == nil {
+		return
+	}
+	return time.Parse(dateLayout, value)
+}
+
+// lintEffective parses effective dates of entry at prefix.
+func (l *linter) lintEffective(e *Effective, prefix ...interface{}) {
+	at := func(elements ...interface{}) []interface{} {
+		return append(append([]interface{}{}, prefix...), elements...)
+	}
+	var err error
+	if e.EffectiveFrom != "" {
+		if e.From, err = parseEffectiveDate(e.EffectiveFrom); err != nil {
+			l.errorf(at("effective_from"), "invalid date %s, expected %s or RFC3339 time", e.EffectiveFrom, dateLayout)
+		}
+	}
+	if e.EffectiveUntil != "" {
+		if e.Until, err = parseEffectiveDate(e.EffectiveUntil); err != nil {
+			l.errorf(at("effective_until"), "invalid date %s, expected %s or RFC3339 time", e.EffectiveUntil, dateLayout)
+		}
+	}
+	if !e.From.IsZero() && !e.Until.IsZero() && !e.From.Before(e.Until) {
+		l.errorf(at("effective_until"), "effective_until must be after effective_from")
+	}
+}
@@ -0,0 +1,180 @@
+package configuration
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// LimitsOverride replaces global work limits for works matching all its selectors (zones,
+// work_type, priority), an empty selector matches any work. Matching overrides are applied in
+// config order, so later ones win.
+type LimitsOverride struct {
+	Zones                   []string `yaml:"zones"`
+	WorkType                string   `yaml:"work_type"`
+	Priority                string   `yaml:"priority"`
+	MinWorkDurationMinutes  *int32   `yaml:"min_work_duration_minutes"`
+	MaxWorkDurationMinutes  *int32   `yaml:"max_work_duration_minutes"`
+	MaxDeadlineDays         *int32   `yaml:"max_deadline_days"`
+	TimeCompressionPercents string   `yaml:"time_compression_percents"`
+	TimeCompressionRate     float32  `yaml:"-"`
+	Effective               `yaml:",inline"`
+}
+
+// Limits are effective limits of a work.
+type Limits struct {
+	MinWorkDurationMinutes int32
+	MaxWorkDurationMinutes int32
+	MaxDeadlineDays        int32
+	// CompressionRate is the lowest share of initial duration the work may be compressed to, 1 if
+	// the work can't be compressed
+	CompressionRate float32
+}
+
+var validTimeCompressionPercents = regexp.MustCompile(`^(?P<num>[0-9]{1,2})%$`)
+
+func parseCompressionPercents(value string) (rate float32, ok bool) {
+	matches := validTimeCompressionPercents.FindStringSubmatch(value)
+	if len(matches) == 0 {
+		return
+	}
+	numValue, _ := strconv.ParseFloat(matches[validTimeCompressionPercents.SubexpIndex("num")], 32)
+	return 1 - (float32(numValue) / float32(100)), true
+}
+
+func (o *LimitsOverride) matches(zone string, workType string, priority string) bool {
+	if len(o.Zones) > 0 && !slices.Contains(o.Zones, zone) {
+		return false
+	}
+	return (o.WorkType == "" || o.WorkType == workType) && (o.Priority == "" || o.Priority == priority)
+}
+
+// zoneLimits applies overrides matching work in zone over global limits, empty zone matches only
+// overrides without zones.
+func (conf *Config) zoneLimits(zone string, workType string, priority string) (limits Limits) {
+	limits.MinWorkDurationMinutes = conf.MinWorkDurationMinutes.Automatic
+	limits.MaxWorkDurationMinutes = conf.MaxWorkDurationMinutes.Automatic
+	if workType == "manual" {
+		limits.MinWorkDurationMinutes = conf.MinWorkDurationMinutes.Manual
+		limits.MaxWorkDurationMinutes = conf.MaxWorkDurationMinutes.Manual
+	}
+	limits.MaxDeadlineDays = conf.MaxDeadlineDays
+	limits.CompressionRate = 1
+	// manual works are not compressed
+	if conf.TimeCompressionPercents != "" && workType != "manual" {
+		limits.CompressionRate = conf.TimeCompressionRate
+	}
+	for _, o := range conf.LimitsOverrides {
+		if !o.matches(zone, workType, priority) {
+			continue
+		}
+		if o.MinWorkDurationMinutes != nil {
+			limits.MinWorkDurationMinutes = *o.MinWorkDurationMinutes
+		}
+		if o.MaxWorkDurationMinutes != nil {
+			limits.MaxWorkDurationMinutes = *o.MaxWorkDurationMinutes
+		}
+		if o.MaxDeadlineDays != nil {
+			limits.MaxDeadlineDays = *o.MaxDeadlineDays
+		}
+		if o.TimeCompressionPercents != "" && workType != "manual" {
+			limits.CompressionRate = o.TimeCompressionRate
+		}
+	}
+	return
+}
+
+// Limits returns limits of a work, for a work in several zones the strictest limits of its zones
+// are used.
+func (conf *Config) Limits(zones []string, workType string, priority string) (limits Limits) {
+	if len(zones) == 0 {
+		return conf.zoneLimits("", workType, priority)
+	}
+	for i, zone := range zones {
+		zl := conf.zoneLimits(zone, workType, priority)
+		if i == 0 {
+			limits = zl
+			continue
+		}
+		if zl.MinWorkDurationMinutes > limits.MinWorkDurationMinutes {
+			limits.MinWorkDurationMinutes = zl.MinWorkDurationMinutes
+		}
+		if zl.MaxWorkDurationMinutes < limits.MaxWorkDurationMinutes {
+			limits.MaxWorkDurationMinutes = zl.MaxWorkDurationMinutes
+		}
+		if zl.MaxDeadlineDays < limits.MaxDeadlineDays {
+			limits.MaxDeadlineDays = zl.MaxDeadlineDays
+		}
+		if zl.CompressionRate > limits.CompressionRate {
+			limits.CompressionRate = zl.CompressionRate
+		}
+	}
+	return
+}
+
+// LongestDeadlineDays is the furthest deadline horizon of any work, it bounds schedule reads.
+func (conf *Config) LongestDeadlineDays() (days int32) {
+	days = conf.MaxDeadlineDays
+	for _, o := range conf.LimitsOverrides {
+		if o.MaxDeadlineDays != nil && *o.MaxDeadlineDays > days {
+			days = *o.MaxDeadlineDays
+		}
+	}
+	return
+}
+
+// LongestWorkMinutes is the longest duration of any regular work.
+func (conf *Config) LongestWorkMinutes() (minutes int32) {
+	minutes = conf.MaxWorkDurationMinutes.Automatic
+	if conf.MaxWorkDurationMinutes.Manual > minutes {
+		minutes = conf.MaxWorkDurationMinutes.Manual
+	}
+	for _, o := range conf.LimitsOverrides {
+		if o.MaxWorkDurationMinutes != nil && *o.MaxWorkDurationMinutes > minutes {
+			minutes = *o.MaxWorkDurationMinutes
+		}
+	}
+	return
+}
+
+func (l *linter) lintLimitsOverrides(conf *Config, zones map[string]time.Time) {
+	for i := range conf.LimitsOverrides {
+		o := &conf.LimitsOverrides[i]
+		for j, zone := range o.Zones {
+			if _, ok := zones[zone]; !ok {
+				l.errorf(path("limits_overrides", i, "zones", j), "zone %s not found in zone (black/white list)", zone)
+			}
+		}
+		if o.WorkType != "" && o.WorkType != "automatic" && o.WorkType != "manual" {
+			l.errorf(path("limits_overrides", i, "work_type"), "work_type must be automatic or manual, got %s", o.WorkType)
+		}
+		if o.Priority != "" && o.Priority != "regular" && o.Priority != "critical" {
+			l.errorf(path("limits_overrides", i, "priority"), "priority must be regular or critical, got %s", o.Priority)
+		}
+		if o.MinWorkDurationMinutes == nil && o.MaxWorkDurationMinutes == nil && o.MaxDeadlineDays == nil && o.TimeCompressionPercents == "" {
+			l.warnf(path("limits_overrides", i), "override has no limits")
+		}
+		if o.MinWorkDurationMinutes != nil && *o.MinWorkDurationMinutes <= 0 {
+			l.errorf(path("limits_overrides", i, "min_work_duration_minutes"), "duration value must be greater then 0")
+		}
+		if o.MaxWorkDurationMinutes != nil && *o.MaxWorkDurationMinutes <= 0 {
+			l.errorf(path("limits_overrides", i, "max_work_duration_minutes"), "duration value must be greater then 0")
+		}
+		if o.MinWorkDurationMinutes != nil && o.MaxWorkDurationMinutes != nil && *o.MinWorkDurationMinutes > *o.MaxWorkDurationMinutes {
+			l.errorf(path("limits_overrides", i, "min_work_duration_minutes"), "duration value can't be greater then max_work_duration_minutes")
+		}
+		if o.MaxDeadlineDays != nil && *o.MaxDeadlineDays <= 0 {
+			l.errorf(path("limits_overrides", i, "max_deadline_days"), "duration value must be greater then 0")
+		}
+		l.lintEffective(&o.Effective, "limits_overrides", i)
+		if o.TimeCompressionPercents != "" {
+			if rate, ok := parseCompressionPercents(o.TimeCompressionPercents); ok {
+				o.TimeCompressionRate = rate
+			} else {
+				l.errorf(path("limits_overrides", i, "time_compression_percents"), "invalid value [%s], expected percents like 20%%", o.TimeCompressionPercents)
+			}
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found in config, Line is 0 if the value is not found in the document, e.g.
+// for zones managed through API.
+type Issue struct {
+	Path     string   `json:"path"`
+	Severity Severity `json:"severity"`
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", i.Line, i.Path, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// ValidationError lists all problems found in config.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	messages := []string{}
+	for _, issue := range e.Issues {
+		if issue.Severity == SeverityError {
+			messages = append(messages, issue.String())
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) HasErrors() bool {
+	for _, issue := range e.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+): `)
+
+// parseError converts yaml error to ValidationError, the line is moved from message to the issue.
+func parseError(err error) *ValidationError {
+	issue := Issue{Severity: SeverityError, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+	if matches := yamlErrorLine.FindStringSubmatch(issue.Message); len(matches) > 0 {
+		issue.Line, _ = strconv.Atoi(matches[1])
+		issue.Message = strings.Replace(issue.Message, matches[0], "", 1)
+	}
+	return &ValidationError{Issues: []Issue{issue}}
+}
+
+// Lint reads config file and returns all its issues, err is returned only if the file can't be
+// read.
+func Lint(path string) (issues []Issue, err error) {
+	document, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	config, err := parseConfig(document)
+	var invalid *ValidationError
+	if errors.As(err, &invalid) {
+		return invalid.Issues, nil
+	}
+	if err != nil {
+		return
+	}
+	return lintConfig(&config), nil
+}
+
+// linter collects issues with lines of their paths in config document.
+type linter struct {
+	root   *yaml.Node
+	issues []Issue
+}
+
+func newLinter(document []byte) *linter {
+	l := &linter{}
+	var root yaml.Node
+	if err := yaml.Unmarshal(document, &root); err == nil && len(root.Content) > 0 {
+		l.root = root.Content[0]
+	}
+	return l
+}
+
+// add reports issue at path, path elements are map keys or sequence indexes.
+func (l *linter) add(severity Severity, message string, path ...interface{}) {
+	l.issues = append(l.issues, Issue{
+		Path:     formatPath(path),
+		Severity: severity,
+		Line:     l.line(path),
+		Message:  message,
+	})
+}
+
+func (l *linter) errorf(path []interface{}, format string, args ...interface{}) {
+	l.add(SeverityError, fmt.Sprintf(format, args...), path...)
+}
+
+func (l *linter) warnf(path []interface{}, format string, args ...interface{}) {
+	l.add(SeverityWarning, fmt.Sprintf(format, args...), path...)
+}
+
+func formatPath(path []interface{}) string {
+	b := strings.Builder{}
+	for _, p := range path {
+		switch p := p.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", p)
+		default:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			fmt.Fprint(&b, p)
+		}
+	}
+	return b.String()
+}
+
+// line returns line of the deepest found path element.
+func (l *linter) line(path []interface{}) (line int) {
+	node := l.root
+	for _, p := range path {
+		if node == nil {
+			return
+		}
+		var next *yaml.Node
+		switch p := p.(type) {
+		case int:
+			if node.Kind == yaml.SequenceNode && p < len(node.Content) {
+				next = node.Content[p]
+			}
+		default:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == fmt.Sprint(p) {
+						// problems of scalar values are reported on the key line
+						line = node.Content[i].Line
+						next = node.Content[i+1]
+						break
+					}
+				}
+			}
+		}
+		if next == nil {
+			return
+		}
+		node = next
+		if node.Kind != yaml.ScalarNode {
+			line = node.Line
+		}
+	}
+	return
+}
+
+// yamlFields returns types of struct fields by yaml keys, fields of inline structs are included.
+func yamlFields(t reflect.Type) (fields map[string]reflect.Type) {
+	fields = make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+		if slices.Contains(tag[1:], "inline") {
+			for key, ft := range yamlFields(field.Type) {
+				fields[key] = ft
+			}
+			continue
+		}
+		if tag[0] != "" && tag[0] != "-" {
+			fields[tag[0]] = field.Type
+		}
+	}
+	return
+}
+
+// lintKeys warns about keys of node which are not decoded into t, nested values are checked too.
+func (l *linter) lintKeys(node *yaml.Node, t reflect.Type, p []interface{}) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// copy to not share backing array between nested paths
+	at := func(element interface{}) []interface{} {
+		return append(append([]interface{}{}, p...), element)
+	}
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			ft, ok := fields[key]
+			if !ok {
+				l.warnf(at(key), "unknown key is ignored")
+				continue
+			}
+			l.lintKeys(node.Content[i+1], ft, at(key))
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			l.lintKeys(node.Content[i+1], t.Elem(), at(node.Content[i].Value))
+		}
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			l.lintKeys(item, t.Elem(), at(i))
+		}
+	}
+}
+
+func path(elements ...interface{}) []interface{} {
+	return elements
+}
+
+// lintConfig normalizes config and returns all its issues sorted by line.
+func lintConfig(conf *Config) []Issue {
+	l := newLinter(conf.Document)
+
+	if l.root != nil {
+		l.lintKeys(l.root, reflect.TypeOf(Config{}), path())
+	}
+
+	ts := time.Now()
+	zones := make(map[string]time.Time)
+	for name, zone := range conf.WhiteList {
+		zones[name] = ts
+		if len(zone) == 0 {
+			l.warnf(path("white_list", name), "zone has no windows, works can't be scheduled in it")
+		}
+		conf.WhiteList[name] = l.lintWindows(zone, "white_list", name)
+	}
+	for i := range conf.BlackListEntries {
+		entry := &conf.BlackListEntries[i]
+		l.lintEffective(&entry.Effective, path("black_list", i)...)
+		// zone may be scheduled to black list, it stays in white list until then
+		if _, ok := conf.WhiteList[entry.Zone]; ok && entry.EffectiveFrom == "" && entry.EffectiveUntil == "" {
+			l.errorf(path("black_list", i), "zone %s from black list in white list", entry.Zone)
+		}
+		zones[entry.Zone] = ts
+	}
+	if conf.MinAvialableZones > int32(len(conf.WhiteList)-2) { //|| conf.MinAvialableZones < 2
+		l.errorf(path("min_avialable_zones"), "min_avialable_zones must not be greater then zone count=%v, got %v", int32(len(conf.WhiteList)-2), conf.MinAvialableZones)
+	}
+
+	for k, v := range conf.PausesMinutes {
+		if _, ok := zones[k]; !ok {
+			l.errorf(path("pauses", k), "zone %s not found in zone (black/white list)", k)
+		}
+		if v < 0 || v > 60 {
+			l.errorf(path("pauses", k), "pause must be in range from 0 to 60, got %d", v)
+		}
+	}
+
+	if conf.MinWorkDurationMinutes.Automatic < 5 {
+		l.errorf(path("min_work_duration_minutes", "automatic"), "duration value for automatic works must be greater then 5")
+	}
+
+	if conf.MinWorkDurationMinutes.Manual < 30 {
+		l.errorf(path("min_work_duration_minutes", "manual"), "duration value for manual works must be greater then 30")
+	}
+
+	if conf.MinWorkDurationMinutes.Automatic > conf.MaxWorkDurationMinutes.Automatic {
+		l.errorf(path("min_work_duration_minutes", "automatic"), "duration value for automatic can't be greater then max_work_duration_minutes")
+	}
+
+	if conf.MinWorkDurationMinutes.Manual > conf.MaxWorkDurationMinutes.Manual {
+		l.errorf(path("min_work_duration_minutes", "manual"), "duration value for manual can't be greater then max_work_duration_minutes")
+	}
+
+	if conf.MaxDeadlineDays <= 0 {
+		l.errorf(path("max_deadline_days"), "duration value must be greater then 0")
+	}
+
+	for status, days := range conf.RetentionDays {
+		if !slices.Contains(RetentionStatuses, status) {
+			l.errorf(path("retention_days", status), "status must be one of %v, got %s", RetentionStatuses, status)
+		}
+		if days <= 0 {
+			l.errorf(path("retention_days", status), "value for %s must be greater then 0", status)
+		}
+	}
+
+	if len(conf.TimeCompressionPercents) != 0 {
+		if rate, ok := parseCompressionPercents(conf.TimeCompressionPercents); ok {
+			conf.TimeCompressionRate = rate
+		} else {
+			l.errorf(path("time_compression_percents"), "invalid value [%s], expected percents like 20%%", conf.TimeCompressionPercents)
+		}
+	}
+
+	l.lintLimitsOverrides(conf, zones)
+	l.lintWhiteListOverrides(conf)
+	l.lintCalendar(conf)
+
+	// map iteration order is random
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		a, b := l.issues[i], l.issues[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Path < b.Path
+	})
+	return l.issues
+}
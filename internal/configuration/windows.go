@@ -0,0 +1,80 @@
+package configuration
+
+import "golang.org/x/exp/slices"
+
+// WindowsOverride replaces white-list windows of zones for works matching its work_type and
+// priority, an empty selector matches any work. Matching overrides are applied in config order,
+// so later ones win.
+type WindowsOverride struct {
+	Zones     []string `yaml:"zones"`
+	WorkType  string   `yaml:"work_type"`
+	Priority  string   `yaml:"priority"`
+	Windows   []Window `yaml:"windows"`
+	Effective `yaml:",inline"`
+}
+
+// Windows returns white-list windows of zone for work, ok is false if zone is not in white list.
+func (conf *Config) Windows(zone string, workType string, priority string) (windows []Window, ok bool) {
+	windows, ok = conf.WhiteList[zone]
+	if !ok {
+		return
+	}
+	for _, o := range conf.WhiteListOverrides {
+		if len(o.Zones) > 0 && !slices.Contains(o.Zones, zone) {
+			continue
+		}
+		if (o.WorkType == "" || o.WorkType == workType) && (o.Priority == "" || o.Priority == priority) {
+			windows = o.Windows
+		}
+	}
+	return
+}
+
+// lintWindows checks windows and splits windows over midnight, prefix is the path of windows.
+func (l *linter) lintWindows(windows []Window, prefix ...interface{}) []Window {
+	at := func(elements ...interface{}) []interface{} {
+		return append(append([]interface{}{}, prefix...), elements...)
+	}
+	additionalIntervals := []Window{}
+	for i := range windows {
+		l.lintEffective(&windows[i].Effective, at(i)...)
+		interval := windows[i]
+		if interval.StartHour >= 24 {
+			l.errorf(at(i, "start_hour"), "start_hour must be uint in range from 0 to 23, got %d", interval.StartHour)
+		}
+		if interval.EndHour >= 24 {
+			l.errorf(at(i, "end_hour"), "end_hour must be uint in range from 0 to 23, got %d", interval.EndHour)
+		}
+		if interval.StartHour >= interval.EndHour {
+			// add new interval above 00:00
+			additionalIntervals = append(additionalIntervals, Window{StartHour: 0, EndHour: interval.EndHour, Effective: interval.Effective})
+			windows[i].EndHour = 24
+		}
+	}
+	return append(windows, additionalIntervals...)
+}
+
+func (l *linter) lintWhiteListOverrides(conf *Config) {
+	for i := range conf.WhiteListOverrides {
+		o := &conf.WhiteListOverrides[i]
+		for j, zone := range o.Zones {
+			if _, ok := conf.WhiteList[zone]; !ok {
+				l.errorf(path("white_list_overrides", i, "zones", j), "zone %s not found in white list", zone)
+			}
+		}
+		if o.WorkType != "" && o.WorkType != "automatic" && o.WorkType != "manual" {
+			l.errorf(path("white_list_overrides", i, "work_type"), "work_type must be automatic or manual, got %s", o.WorkType)
+		}
+		if o.Priority != "" && o.Priority != "regular" && o.Priority != "critical" {
+			l.errorf(path("white_list_overrides", i, "priority"), "priority must be regular or critical, got %s", o.Priority)
+		}
+		if o.WorkType == "" && o.Priority == "" {
+			l.warnf(path("white_list_overrides", i), "override without work_type and priority replaces zone windows for all works")
+		}
+		if len(o.Windows) == 0 {
+			l.warnf(path("white_list_overrides", i, "windows"), "override has no windows, matching works can't be scheduled")
+		}
+		l.lintEffective(&o.Effective, "white_list_overrides", i)
+		o.Windows = l.lintWindows(o.Windows, "white_list_overrides", i, "windows")
+	}
+}
@@ -0,0 +1,97 @@
+package configuration
+
+import (
+	"log"
+	"reflect"
+)
+
+// Zone is availability zone managed through API, it replaces zone with the same id from config
+// file in the effective config.
+type Zone struct {
+	ZoneId       string   `bson:"zoneId" json:"zoneId"`
+	Windows      []Window `bson:"windows" json:"windows"`
+	PauseMinutes int32    `bson:"pauseMinutes" json:"pauseMinutes"`
+	BlackListed  bool     `bson:"blackListed" json:"blackListed"`
+	// Decommissioned zone is removed from config even if it is in config file
+	Decommissioned bool `bson:"decommissioned" json:"decommissioned"`
+}
+
+func mergeZones(conf *Config, zones []Zone) {
+	if conf.WhiteList == nil {
+		conf.WhiteList = make(map[string][]Window)
+	}
+	if conf.PausesMinutes == nil {
+		conf.PausesMinutes = make(map[string]int32)
+	}
+	for _, zone := range zones {
+		delete(conf.WhiteList, zone.ZoneId)
+		delete(conf.PausesMinutes, zone.ZoneId)
+		entries := []BlackListEntry{}
+		for _, e := range conf.BlackListEntries {
+			if e.Zone != zone.ZoneId {
+				entries = append(entries, e)
+			}
+		}
+		conf.BlackListEntries = entries
+		if zone.Decommissioned {
+			continue
+		}
+		if zone.BlackListed {
+			conf.BlackListEntries = append(conf.BlackListEntries, BlackListEntry{Zone: zone.ZoneId})
+		} else {
+			conf.WhiteList[zone.ZoneId] = append([]Window{}, zone.Windows...)
+		}
+		if zone.PauseMinutes != 0 {
+			conf.PausesMinutes[zone.ZoneId] = zone.PauseMinutes
+		}
+	}
+}
+
+// Zones returns zones managed through API.
+func (c *Configurator) Zones() []Zone {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	return append([]Zone{}, c.zones...)
+}
+
+// ValidateZones checks the effective config with zones without applying it.
+func (c *Configurator) ValidateZones(zones []Zone) error {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	_, err := c.withZones(zones)
+	return err
+}
+
+// SetZones applies zones over config file as the next config version, config is not changed if
+// the effective config is invalid.
+func (c *Configurator) SetZones(zones []Zone) (conf *Config, err error) {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	if reflect.DeepEqual(zones, c.zones) || len(zones) == 0 && len(c.zones) == 0 {
+		conf = c.Current()
+		return
+	}
+	conf, err = c.withZones(zones)
+	if err != nil {
+		return
+	}
+	c.zones = zones
+	c.publish(conf)
+	log.Printf("Configuration updated Successfuly! Version %d\n", conf.Version)
+	return
+}
+
+// withZones builds config from the current document, normalized windows of the current config
+// can't be validated again.
+func (c *Configurator) withZones(zones []Zone) (conf *Config, err error) {
+	config, err := parseConfig(c.Current().Document)
+	if err != nil {
+		return
+	}
+	mergeZones(&config, zones)
+	if err = c.validateConfig(&config); err != nil {
+		return
+	}
+	conf = &config
+	return
+}
@@ -0,0 +1,73 @@
+package holds
+
+import (
+	"context"
+	"log"
+	"time"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+)
+
+const interval = time.Minute
+
+// Releaser cancels held works which were not confirmed until their hold expired.
+type Releaser struct {
+	Repository repository.ReadWriteRepository
+	Config     *configuration.Configurator
+}
+
+func NewReleaser(repo repository.ReadWriteRepository, config *configuration.Configurator) *Releaser {
+	return &Releaser{
+		Repository: repo,
+		Config:     config,
+	}
+}
+
+func (r *Releaser) Run(ctx context.Context) {
+	go r.process(ctx)
+}
+
+func (r *Releaser) process(ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.ReleaseExpired(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReleaseExpired cancels held works with hold expired before now. Works can't be planned later
+// than max_deadline_days, so only that range is searched.
+func (r *Releaser) ReleaseExpired(ctx context.Context, now time.Time) (released int) {
+	to := now.Add(time.Duration(r.Config.Current().LongestDeadlineDays()) * 24 * time.Hour)
+	works, err := r.Repository.List(ctx, time.Unix(0, 0), to, []string{}, []string{string(models.StatusHeld)}, repository.ListModeOverlaps)
+	if err != nil {
+		log.Printf("WARNING: Find error while getting held works from data, %s\n", err)
+		return
+	}
+	for _, work := range works {
+		if now.Before(work.HoldUntil) {
+			continue
+		}
+		if err := work.ChangeStatus(models.StatusCanceled, models.ActorReleaser); err != nil {
+			log.Printf("WARNING: Find error while releasing work %s, %s\n", work.WorkId, err)
+			continue
+		}
+		work.Reason = "hold expired"
+		if _, err := r.Repository.Update(ctx, work); err != nil {
+			log.Printf("WARNING: Find error while releasing work %s, %s\n", work.WorkId, err)
+			continue
+		}
+		released++
+	}
+	if released > 0 {
+		log.Printf("successfully released %v expired held works\n", released)
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package holds
+
+import (
+	"context"
+	"testing"
+	"time"
+	"workScheduler/internal/configuration"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
+	"workScheduler/internal/scheduler/models"
+)
+
+func TestReleaseExpired(t *testing.T) {
+
+	t.Run("succees release expired holds", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Date(2023, 04, 19, 10, 0, 0, 0, time.UTC)
+
+		repo := inmemoryrepository.NewInmemoryRepository()
+		works := []*models.WorkItem{
+			{WorkId: "expired", StartDate: now.Add(2 * time.Hour), HoldUntil: now.Add(-time.Minute), Status: "held"},
+			{WorkId: "held", StartDate: now.Add(2 * time.Hour), HoldUntil: now.Add(time.Hour), Status: "held"},
+			{WorkId: "planned", StartDate: now.Add(2 * time.Hour), Status: "planned"},
+		}
+		for _, work := range works {
+			work.Zones = []string{"Zone_1"}
+			work.DurationMinutes = 60
+			repo.Add(ctx, work)
+		}
+		config := configuration.NewStaticConfigurator(&configuration.Config{MaxDeadlineDays: 7})
+		r := NewReleaser(repo, config)
+
+		if released := r.ReleaseExpired(ctx, now); released != 1 {
+			t.Errorf("unexpected released count: %v, want 1", released)
+		}
+		expected := map[string]models.Status{"expired": "canceled", "held": "held", "planned": "planned"}
+		for workId, status := range expected {
+			stored, err := repo.GetById(ctx, workId)
+			if err != nil || stored[0].Status != status {
+				t.Errorf("unexpected work %s: %v %v, want status %s", workId, stored, err, status)
+			}
+		}
+		if released := r.ReleaseExpired(ctx, now.Add(2*time.Hour)); released != 1 {
+			t.Errorf("unexpected released count after the second hold expired: %v, want 1", released)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+
+	"github.com/google/uuid"
+)
+
+const DefaultTTL = 15 * time.Second
+
+// Elector holds a lease in storage, only the replica holding it runs background jobs. The lease
+// is renewed every third of TTL, so another replica takes it over at most TTL after the leader died.
+type Elector struct {
+	Leases repository.LeaseRepository
+	Name   string
+	Holder string
+	TTL    time.Duration
+
+	mu    sync.Mutex
+	token int64
+	done  chan struct{}
+}
+
+func NewElector(leases repository.LeaseRepository, name string, ttl time.Duration) *Elector {
+	hostname, _ := os.Hostname()
+	return &Elector{
+		Leases: leases,
+		Name:   name,
+		Holder: fmt.Sprintf("%s/%s", hostname, uuid.NewString()),
+		TTL:    ttl,
+	}
+}
+
+// Run calls onElected each time the replica becomes the leader, ctx of onElected is canceled
+// when leadership is lost or ctx is done.
+func (e *Elector) Run(ctx context.Context, onElected func(ctx context.Context)) {
+	e.done = make(chan struct{})
+	go e.elect(ctx, onElected)
+}
+
+// Wait returns after the elector stopped and released the lease, ctx of Run must be done.
+func (e *Elector) Wait() {
+	if e.done != nil {
+		<-e.done
+	}
+}
+
+func (e *Elector) elect(ctx context.Context, onElected func(ctx context.Context)) {
+	defer close(e.done)
+	ticker := time.NewTicker(e.TTL / 3)
+	defer ticker.Stop()
+
+	var (
+		cancel    context.CancelFunc
+		expiresAt time.Time
+	)
+	resign := func() {
+		if cancel != nil {
+			cancel()
+			cancel = nil
+			e.setToken(0)
+			log.Printf("WARNING: %s lost leadership of %s\n", e.Holder, e.Name)
+		}
+	}
+
+	for {
+		start := time.Now()
+		lease, acquired, err := e.Leases.AcquireLease(ctx, e.Name, e.Holder, e.TTL)
+		switch {
+		case err != nil:
+			log.Printf("WARNING: Find error while acquiring lease %s, %s\n", e.Name, err)
+			// storage may be unavailable for a while, leadership is kept until local lease expiration
+			if time.Now().After(expiresAt) {
+				resign()
+			}
+		case !acquired:
+			resign()
+		default:
+			// counted from the request start, so local deadline is not later than in storage
+			expiresAt = start.Add(e.TTL)
+			if cancel == nil || e.Token() != lease.Token {
+				resign()
+				e.setToken(lease.Token)
+				log.Printf("successfully elected %s as leader of %s with token %d\n", e.Holder, e.Name, lease.Token)
+				leaderCtx, leaderCancel := context.WithCancel(ctx)
+				cancel = leaderCancel
+				onElected(leaderCtx)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			resign()
+			// let other replica take over without waiting for the lease expiration
+			releaseCtx, releaseCancel := context.WithTimeout(context.Background(), e.TTL/3)
+			if err := e.Leases.ReleaseLease(releaseCtx, e.Name, e.Holder); err != nil {
+				log.Printf("WARNING: Find error while releasing lease %s, %s\n", e.Name, err)
+			}
+			releaseCancel()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Token returns fencing token of the current leadership, 0 if the replica is not the leader.
+func (e *Elector) Token() int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.token
+}
+
+func (e *Elector) setToken(token int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.token = token
+}
+
+// Check returns an error if the replica is not the leader anymore.
+func (e *Elector) Check(ctx context.Context) error {
+	token := e.Token()
+	if token == 0 {
+		return fmt.Errorf("%s is not the leader of %s", e.Holder, e.Name)
+	}
+	return e.Leases.VerifyLease(ctx, e.Name, token)
+}
+
+// Fence returns repository which writes only while the replica holds the lease with the same
+// token, so jobs of a former leader can't overwrite changes made under the new one. The token is
+// verified right before the write and not in the same storage operation, so a former leader may
+// still finish one write started before the lease was taken over.
+func (e *Elector) Fence(repo repository.ReadWriteRepository) repository.ReadWriteRepository {
+	return &fencedRepository{
+		ReadWriteRepository: repo,
+		elector:             e,
+	}
+}
+
+type fencedRepository struct {
+	repository.ReadWriteRepository
+	elector *Elector
+}
+
+func (f *fencedRepository) Add(ctx context.Context, work *models.WorkItem) (*models.WorkItem, error) {
+	if err := f.elector.Check(ctx); err != nil {
+		return nil, err
+	}
+	return f.ReadWriteRepository.Add(ctx, work)
+}
+
+func (f *fencedRepository) Update(ctx context.Context, work *models.WorkItem) (*models.WorkItem, error) {
+	if err := f.elector.Check(ctx); err != nil {
+		return nil, err
+	}
+	return f.ReadWriteRepository.Update(ctx, work)
+}
+
+func (f *fencedRepository) Delete(ctx context.Context, work *models.WorkItem) error {
+	if err := f.elector.Check(ctx); err != nil {
+		return err
+	}
+	return f.ReadWriteRepository.Delete(ctx, work)
+}
@@ -0,0 +1,66 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
+	"workScheduler/internal/scheduler/models"
+)
+
+func waitFor(t *testing.T, condition func() bool, message string) {
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal(message)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestElector(t *testing.T) {
+
+	t.Run("succees failover to another replica", func(t *testing.T) {
+		repo := inmemoryrepository.NewInmemoryRepository()
+		ttl := 150 * time.Millisecond
+
+		firstCtx, firstStop := context.WithCancel(context.Background())
+		defer firstStop()
+		first := NewElector(repo, "jobs", ttl)
+		firstJobs := make(chan context.Context, 1)
+		first.Run(firstCtx, func(ctx context.Context) { firstJobs <- ctx })
+		waitFor(t, func() bool { return first.Token() != 0 }, "first replica is not elected")
+		jobsCtx := <-firstJobs
+
+		secondCtx, secondStop := context.WithCancel(context.Background())
+		defer secondStop()
+		second := NewElector(repo, "jobs", ttl)
+		second.Run(secondCtx, func(ctx context.Context) {})
+		time.Sleep(2 * ttl)
+		if second.Token() != 0 {
+			t.Fatalf("second replica is elected while first holds the lease")
+		}
+
+		fenced := first.Fence(repo)
+		if _, err := fenced.Add(context.Background(), &models.WorkItem{WorkId: "1", Status: models.StatusPlanned}); err != nil {
+			t.Errorf("unexpected error on leader write: %v", err)
+		}
+		firstToken := first.Token()
+
+		firstStop()
+		first.Wait()
+		if err := repo.VerifyLease(context.Background(), "jobs", firstToken); err == nil {
+			t.Errorf("lease of stopped leader is not released")
+		}
+		waitFor(t, func() bool { return second.Token() != 0 }, "second replica is not elected after first stopped")
+		if second.Token() <= firstToken {
+			t.Errorf("unexpected token of new leader: %v, want greater than %v", second.Token(), firstToken)
+		}
+		if jobsCtx.Err() == nil {
+			t.Errorf("jobs of former leader are not stopped")
+		}
+		if _, err := fenced.Add(context.Background(), &models.WorkItem{WorkId: "2", Status: models.StatusPlanned}); err == nil {
+			t.Errorf("write of former leader is not fenced")
+		}
+	})
+}
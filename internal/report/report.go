@@ -0,0 +1,71 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"workScheduler/internal/scheduler/models"
+)
+
+type GroupBy string
+
+const (
+	GroupByZone     GroupBy = "zone"
+	GroupByWorkType GroupBy = "workType"
+	GroupByTeam     GroupBy = "team"
+)
+
+// Row compares planned and actual durations of finished works in a group.
+type Row struct {
+	Key                 string `json:"key"`
+	Works               int32  `json:"works"`
+	PlannedMinutes      int32  `json:"plannedMinutes"`
+	ActualMinutes       int32  `json:"actualMinutes"`
+	DeviationMinutes    int32  `json:"deviationMinutes"`
+	Prolongations       int32  `json:"prolongations"`
+	ProlongationMinutes int32  `json:"prolongationMinutes"`
+}
+
+// Build groups works with actual start and end, work in several zones is counted in each of
+// them. Works without team are reported with empty key.
+func Build(works []*models.WorkItem, groupBy GroupBy) (rows []Row, err error) {
+	byKey := make(map[string]*Row)
+	for _, work := range works {
+		if work.ActualStart.IsZero() || work.ActualEnd.IsZero() {
+			continue
+		}
+		var keys []string
+		switch groupBy {
+		case GroupByZone:
+			keys = work.Zones
+		case GroupByWorkType:
+			keys = []string{work.WorkType}
+		case GroupByTeam:
+			keys = []string{work.Team}
+		default:
+			err = fmt.Errorf("unknown report grouping %q", groupBy)
+			return
+		}
+		for _, key := range keys {
+			row, ok := byKey[key]
+			if !ok {
+				row = &Row{Key: key}
+				byKey[key] = row
+			}
+			row.Works++
+			row.PlannedMinutes += work.PlannedDuration()
+			row.ActualMinutes += work.ActualDuration()
+			row.DeviationMinutes = row.ActualMinutes - row.PlannedMinutes
+			row.Prolongations += work.ProlongationCount
+			row.ProlongationMinutes += work.ProlongationMinutes
+		}
+	}
+
+	rows = []Row{}
+	for _, row := range byKey {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Key < rows[j].Key
+	})
+	return
+}
@@ -0,0 +1,44 @@
+package report
+
+import (
+	"testing"
+	"time"
+	"workScheduler/internal/scheduler/models"
+)
+
+func TestBuild(t *testing.T) {
+
+	t.Run("succees group finished works", func(t *testing.T) {
+		start := time.Date(2023, 04, 19, 10, 0, 0, 0, time.UTC)
+		prolongated := &models.WorkItem{Zones: []string{"Zone_1", "Zone_2"}, WorkType: "manual", Team: "sre", StartDate: start, DurationMinutes: 60, InitialDuration: 60}
+		prolongated.Prolongate(90)
+		prolongated.ActualStart, prolongated.ActualEnd = start, start.Add(85*time.Minute)
+		works := []*models.WorkItem{
+			prolongated,
+			{Zones: []string{"Zone_1"}, WorkType: "automatic", StartDate: start, DurationMinutes: 30, InitialDuration: 30, ActualStart: start, ActualEnd: start.Add(30 * time.Minute)},
+			// not finished works are skipped
+			{Zones: []string{"Zone_1"}, WorkType: "manual", Team: "sre", StartDate: start, DurationMinutes: 30, ActualStart: start},
+		}
+
+		rows, err := Build(works, GroupByZone)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []Row{
+			{Key: "Zone_1", Works: 2, PlannedMinutes: 90, ActualMinutes: 115, DeviationMinutes: 25, Prolongations: 1, ProlongationMinutes: 30},
+			{Key: "Zone_2", Works: 1, PlannedMinutes: 60, ActualMinutes: 85, DeviationMinutes: 25, Prolongations: 1, ProlongationMinutes: 30},
+		}
+		if len(rows) != len(expected) || rows[0] != expected[0] || rows[1] != expected[1] {
+			t.Errorf("unexpected rows by zone: %v, want %v", rows, expected)
+		}
+
+		rows, err = Build(works, GroupByTeam)
+		if err != nil || len(rows) != 2 || rows[0].Key != "" || rows[1].Key != "sre" || rows[1].Works != 1 {
+			t.Errorf("unexpected rows by team: %v %v", rows, err)
+		}
+
+		if _, err = Build(works, "priority"); err == nil {
+			t.Errorf("expected error for unknown grouping")
+		}
+	})
+}
@@ -0,0 +1,267 @@
+package boltrepository
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"time"
+
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/exp/slices"
+)
+
+const defaultPath = "scheduler.db"
+
+var (
+	worksBucket       = []byte("works")
+	startDateBucket   = []byte("works_by_start_date")
+	endDateBucket     = []byte("works_by_end_date")
+	workIdIndexBucket = []byte("works_by_work_id")
+)
+
+// BoltRepository stores works in a single bolt file, documents are encoded with bson
+// as in mongo and indexed by start date, end date and work id.
+type BoltRepository struct {
+	db *bolt.DB
+}
+
+func NewBoltRepository(ctx context.Context, path string) (r *BoltRepository, err error) {
+	if path == "" {
+		path = defaultPath
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{worksBucket, startDateBucket, endDateBucket, workIdIndexBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return
+	}
+	log.Printf("opened bolt storage %s\n", path)
+
+	r = &BoltRepository{
+		db: db,
+	}
+	return
+}
+
+var _ repository.ReadWriteRepository = (*BoltRepository)(nil)
+
+func (b *BoltRepository) Close() error {
+	return b.db.Close()
+}
+
+func (b *BoltRepository) Add(ctx context.Context, work *models.WorkItem) (result *models.WorkItem, err error) {
+	if work.Id.IsZero() {
+		work.Id = primitive.NewObjectID()
+	}
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		return putWork(tx, work)
+	})
+	if err != nil {
+		return
+	}
+	result = work
+	log.Printf("successfully inserted work with document id %v\n", work.Id)
+	return
+}
+
+func (b *BoltRepository) Update(ctx context.Context, work *models.WorkItem) (result *models.WorkItem, err error) {
+	if work.Id.IsZero() {
+		work.Id = primitive.NewObjectID()
+	}
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		old, err := getWork(tx, work.Id[:])
+		if err != nil {
+			return err
+		}
+		if old != nil {
+			if err := deleteIndexes(tx, old); err != nil {
+				return err
+			}
+		}
+		return putWork(tx, work)
+	})
+	if err != nil {
+		return
+	}
+	result = work
+	log.Printf("successfully updated work document with id %v\n", work.Id)
+	return
+}
+
+func (b *BoltRepository) Delete(ctx context.Context, work *models.WorkItem) (err error) {
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		old, err := getWork(tx, work.Id[:])
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			return repository.NewErrorNotFound(fmt.Sprintf("work document with id %s not found", work.Id.Hex()))
+		}
+		if err := deleteIndexes(tx, old); err != nil {
+			return err
+		}
+		return tx.Bucket(worksBucket).Delete(work.Id[:])
+	})
+	if err != nil {
+		return
+	}
+	log.Printf("successfully deleted work document with id %v\n", work.Id)
+	return
+}
+
+func (b *BoltRepository) GetById(ctx context.Context, id string) (results []*models.WorkItem, err error) {
+	err = b.db.View(func(tx *bolt.Tx) error {
+		prefix := workIdKey(id, primitive.NilObjectID)[:len(id)+1]
+		c := tx.Bucket(workIdIndexBucket).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			wi, err := getWork(tx, k[len(prefix):])
+			if err != nil {
+				return err
+			}
+			if wi != nil {
+				results = append(results, wi)
+			}
+		}
+		return nil
+	})
+	if err == nil && len(results) == 0 {
+		err = repository.NewErrorNotFound(fmt.Sprintf("work with id %s not found", id))
+	}
+	repository.OrderByStartDate(results)
+	return
+}
+
+func (b *BoltRepository) List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string, mode repository.ListMode) (result []*models.WorkItem, err error) {
+	// starts_in scans start date index in the range, overlaps and ends_in scan end date index
+	// from the range start: works ended before it can't match
+	index, lower, upper := endDateBucket, timeKey(from), []byte(nil)
+	switch mode {
+	case repository.ListModeStartsIn:
+		index, upper = startDateBucket, timeKey(to)
+	case repository.ListModeEndsIn:
+		upper = timeKey(to)
+	}
+	err = b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(index).Cursor()
+		for k, _ := c.Seek(lower); k != nil; k, _ = c.Next() {
+			if upper != nil && bytes.Compare(k[:timeKeySize], upper) > 0 {
+				break
+			}
+			wi, err := getWork(tx, k[timeKeySize:])
+			if err != nil {
+				return err
+			}
+			if wi == nil || !mode.Match(wi.StartDate, wi.EndTime(), from, to) {
+				continue
+			}
+			if len(zones) > 0 && !containsAny(zones, wi.Zones) {
+				continue
+			}
+			if len(statuses) > 0 && !slices.Contains(statuses, string(wi.Status)) {
+				continue
+			}
+			result = append(result, wi)
+		}
+		return nil
+	})
+	// end date index returns works in order of their ends
+	repository.OrderByStartDate(result)
+	return
+}
+
+func (b *BoltRepository) ListPage(ctx context.Context, query repository.PageQuery) (page repository.Page, err error) {
+	works, err := b.List(ctx, query.From, query.To, query.Zones, query.Statuses, query.Mode)
+	if err != nil {
+		return
+	}
+	return repository.PageOf(works, query)
+}
+
+func putWork(tx *bolt.Tx, work *models.WorkItem) error {
+	if err := work.ValidateStatus(); err != nil {
+		return err
+	}
+	work.EndDate = work.EndTime()
+	doc, err := bson.Marshal(work)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(worksBucket).Put(work.Id[:], doc); err != nil {
+		return err
+	}
+	if err := tx.Bucket(startDateBucket).Put(indexKey(work.StartDate, work.Id), []byte{}); err != nil {
+		return err
+	}
+	if err := tx.Bucket(endDateBucket).Put(indexKey(work.EndTime(), work.Id), []byte{}); err != nil {
+		return err
+	}
+	return tx.Bucket(workIdIndexBucket).Put(workIdKey(work.WorkId, work.Id), []byte{})
+}
+
+func deleteIndexes(tx *bolt.Tx, work *models.WorkItem) error {
+	if err := tx.Bucket(startDateBucket).Delete(indexKey(work.StartDate, work.Id)); err != nil {
+		return err
+	}
+	if err := tx.Bucket(endDateBucket).Delete(indexKey(work.EndTime(), work.Id)); err != nil {
+		return err
+	}
+	return tx.Bucket(workIdIndexBucket).Delete(workIdKey(work.WorkId, work.Id))
+}
+
+func getWork(tx *bolt.Tx, id []byte) (*models.WorkItem, error) {
+	doc := tx.Bucket(worksBucket).Get(id)
+	if doc == nil {
+		return nil, nil
+	}
+	var wi models.WorkItem
+	if err := bson.Unmarshal(doc, &wi); err != nil {
+		return nil, fmt.Errorf("unable to decode work document %x: %w", id, err)
+	}
+	return &wi, nil
+}
+
+const timeKeySize = 12
+
+// timeKey encodes time so that byte order of keys matches chronological order.
+func timeKey(t time.Time) []byte {
+	key := make([]byte, timeKeySize)
+	binary.BigEndian.PutUint64(key, uint64(t.Unix())^(1<<63))
+	binary.BigEndian.PutUint32(key[8:], uint32(t.Nanosecond()))
+	return key
+}
+
+func indexKey(t time.Time, id primitive.ObjectID) []byte {
+	return append(timeKey(t), id[:]...)
+}
+
+func workIdKey(workId string, id primitive.ObjectID) []byte {
+	key := append([]byte(workId), 0)
+	return append(key, id[:]...)
+}
+
+func containsAny(arr []string, values []string) bool {
+	for _, v := range values {
+		if slices.Contains(arr, v) {
+			return true
+		}
+	}
+	return false
+}
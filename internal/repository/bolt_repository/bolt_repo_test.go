@@ -0,0 +1,21 @@
+package boltrepository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/repository/repositorytest"
+)
+
+func TestContract(t *testing.T) {
+	repositorytest.RunContractTests(t, func(t *testing.T) repository.ReadWriteRepository {
+		path := filepath.Join(t.TempDir(), "scheduler.db")
+		repo, err := NewBoltRepository(context.Background(), path)
+		if err != nil {
+			t.Fatalf("unable to open bolt repository: %v", err)
+		}
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	})
+}
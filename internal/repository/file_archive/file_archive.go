@@ -0,0 +1,179 @@
+package filearchive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/exp/slices"
+)
+
+const defaultPath = "archive"
+
+// FileArchive stores archived works as gzip compressed JSONL files, one file per month of
+// work start date. Each Archive call appends a new gzip member to the file, works are
+// encoded as relaxed extended JSON with the same field names as in mongo.
+type FileArchive struct {
+	Dir string
+	Mu  *sync.Mutex
+}
+
+func NewFileArchive(dir string) (a *FileArchive, err error) {
+	if dir == "" {
+		dir = defaultPath
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	log.Printf("using file archive in %s\n", dir)
+	a = &FileArchive{
+		Dir: dir,
+		Mu:  &sync.Mutex{},
+	}
+	return
+}
+
+var _ repository.Archive = (*FileArchive)(nil)
+
+func (a *FileArchive) fileName(month time.Time) string {
+	return filepath.Join(a.Dir, fmt.Sprintf("works-%s.jsonl.gz", month.UTC().Format("2006-01")))
+}
+
+func (a *FileArchive) Archive(ctx context.Context, works []*models.WorkItem) error {
+	a.Mu.Lock()
+	defer a.Mu.Unlock()
+
+	byFile := make(map[string][]*models.WorkItem)
+	for _, work := range works {
+		name := a.fileName(work.StartDate)
+		byFile[name] = append(byFile[name], work)
+	}
+	for name, works := range byFile {
+		if err := a.appendFile(name, works); err != nil {
+			return fmt.Errorf("unable to archive works to %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (a *FileArchive) appendFile(name string, works []*models.WorkItem) error {
+	archived, err := readFile(name)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	count := 0
+	for _, work := range works {
+		if slices.ContainsFunc(archived, func(w *models.WorkItem) bool { return w.Id == work.Id }) {
+			continue
+		}
+		work.EndDate = work.EndTime()
+		line, err := bson.MarshalExtJSON(work, false, false)
+		if err != nil {
+			return err
+		}
+		zw.Write(append(line, '\n'))
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	log.Printf("successfully archived %v works to %s\n", count, name)
+	return file.Close()
+}
+
+func readFile(name string) (works []*models.WorkItem, err error) {
+	file, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return
+	}
+	defer zr.Close()
+	reader := bufio.NewReader(zr)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			work := &models.WorkItem{}
+			if err = bson.UnmarshalExtJSON(line, false, work); err != nil {
+				return
+			}
+			works = append(works, work)
+		}
+		if readErr == io.EOF {
+			return
+		}
+		if readErr != nil {
+			err = readErr
+			return
+		}
+	}
+}
+
+// ListArchived reads files of months from the month before from up to to, works started in
+// the previous month may still overlap the range.
+func (a *FileArchive) ListArchived(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string) (result []*models.WorkItem, err error) {
+	a.Mu.Lock()
+	defer a.Mu.Unlock()
+
+	result = []*models.WorkItem{}
+	from, to = from.UTC(), to.UTC()
+	month := time.Date(from.Year(), from.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+	for ; !month.After(to); month = month.AddDate(0, 1, 0) {
+		works, err := readFile(a.fileName(month))
+		if err != nil {
+			return nil, err
+		}
+		for _, work := range works {
+			if !repository.ListModeOverlaps.Match(work.StartDate, work.EndTime(), from, to) {
+				continue
+			}
+			if len(zones) > 0 && !slices.ContainsFunc(work.Zones, func(zone string) bool { return slices.Contains(zones, zone) }) {
+				continue
+			}
+			if len(statuses) > 0 && !slices.Contains(statuses, string(work.Status)) {
+				continue
+			}
+			result = append(result, work)
+		}
+	}
+	repository.OrderByStartDate(result)
+	return
+}
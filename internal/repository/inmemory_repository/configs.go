@@ -0,0 +1,47 @@
+package inmemoryrepository
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"workScheduler/internal/repository"
+)
+
+var _ repository.ConfigHistoryRepository = (*InMemoryRepository)(nil)
+
+func (inm *InMemoryRepository) SaveConfig(ctx context.Context, document string, previous int64) (repository.ConfigRevision, error) {
+	inm.Mu.Lock()
+	defer inm.Mu.Unlock()
+
+	if previous != int64(len(inm.Configs)) {
+		return repository.ConfigRevision{}, repository.NewErrorConflict(fmt.Sprintf("config revision %d is already saved", previous+1))
+	}
+	revision := repository.ConfigRevision{
+		Revision:  int64(len(inm.Configs)) + 1,
+		Document:  document,
+		CreatedAt: time.Now(),
+	}
+	inm.Configs = append(inm.Configs, revision)
+	return revision, nil
+}
+
+func (inm *InMemoryRepository) ListConfigs(ctx context.Context) ([]repository.ConfigRevision, error) {
+	inm.Mu.Lock()
+	defer inm.Mu.Unlock()
+
+	revisions := []repository.ConfigRevision{}
+	for i := len(inm.Configs) - 1; i >= 0; i-- {
+		revisions = append(revisions, inm.Configs[i])
+	}
+	return revisions, nil
+}
+
+func (inm *InMemoryRepository) GetConfig(ctx context.Context, revision int64) (repository.ConfigRevision, error) {
+	inm.Mu.Lock()
+	defer inm.Mu.Unlock()
+
+	if revision < 1 || revision > int64(len(inm.Configs)) {
+		return repository.ConfigRevision{}, repository.NewErrorNotFound(fmt.Sprintf("config revision %d not found", revision))
+	}
+	return inm.Configs[revision-1], nil
+}
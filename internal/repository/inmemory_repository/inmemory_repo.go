@@ -5,24 +5,38 @@ import (
 	"fmt"
 	"sync"
 	"time"
+	"workScheduler/internal/configuration"
 	"workScheduler/internal/repository"
 	"workScheduler/internal/scheduler/models"
 
-	"github.com/gofrs/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// InMemoryRepository keeps work documents in memory keyed by document id, one work
+// may be stored as several per-zone documents sharing the same WorkId (as in mongo).
 type InMemoryRepository struct {
-	Data map[string]*models.WorkItem
-	Mu   *sync.Mutex
+	Data    map[string]*models.WorkItem
+	Leases  map[string]*repository.Lease
+	Configs []repository.ConfigRevision
+	Zones   map[string]configuration.Zone
+	Mu      *sync.Mutex
+
+	stopSnapshots chan struct{}
+	snapshotsDone chan struct{}
+	closeOnce     sync.Once
 }
 
 func NewInmemoryRepository() *InMemoryRepository {
 	return &InMemoryRepository{
-		Data: make(map[string]*models.WorkItem),
-		Mu:   &sync.Mutex{},
+		Data:   make(map[string]*models.WorkItem),
+		Leases: make(map[string]*repository.Lease),
+		Zones:  make(map[string]configuration.Zone),
+		Mu:     &sync.Mutex{},
 	}
 }
 
+var _ repository.ReadWriteRepository = (*InMemoryRepository)(nil)
+
 func inArray(arr []string, i []string) bool {
 	if len(arr) == 0 {
 		return true
@@ -37,54 +51,93 @@ func inArray(arr []string, i []string) bool {
 	return false
 }
 
-func (inm *InMemoryRepository) GetById(ctx context.Context, id string) (*models.WorkItem, error) {
+func copyWork(work *models.WorkItem) *models.WorkItem {
+	c := *work
+	c.Zones = append([]string(nil), work.Zones...)
+	return &c
+}
+
+func (inm *InMemoryRepository) GetById(ctx context.Context, id string) ([]*models.WorkItem, error) {
 	inm.Mu.Lock()
 	defer inm.Mu.Unlock()
 
-	work, ok := inm.Data[id]
-	if !ok {
-		return nil, repository.NewErrorNotFound(fmt.Sprintf("Work with id %s not found", id))
+	var works []*models.WorkItem
+	for _, work := range inm.Data {
+		if work.WorkId == id {
+			works = append(works, copyWork(work))
+		}
 	}
-
-	return work, nil
+	if len(works) == 0 {
+		return nil, repository.NewErrorNotFound(fmt.Sprintf("work with id %s not found", id))
+	}
+	repository.OrderByStartDate(works)
+	return works, nil
 }
 
-func (inm *InMemoryRepository) List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string) ([]*models.WorkItem, error) {
+func (inm *InMemoryRepository) List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string, mode repository.ListMode) ([]*models.WorkItem, error) {
 	inm.Mu.Lock()
 	defer inm.Mu.Unlock()
 
 	works := []*models.WorkItem{}
 
 	for _, work := range inm.Data {
-		if work.StartDate.Unix() >= from.Unix() && work.StartDate.Unix() < to.Unix() && inArray(zones, work.Zones) && inArray(statuses, []string{work.Status}) {
-			works = append(works, work)
+		if mode.Match(work.StartDate, work.EndTime(), from, to) && inArray(zones, work.Zones) && inArray(statuses, []string{string(work.Status)}) {
+			works = append(works, copyWork(work))
 		}
 	}
+	repository.OrderByStartDate(works)
 	return works, nil
 }
 
+func (inm *InMemoryRepository) ListPage(ctx context.Context, query repository.PageQuery) (repository.Page, error) {
+	works, err := inm.List(ctx, query.From, query.To, query.Zones, query.Statuses, query.Mode)
+	if err != nil {
+		return repository.Page{}, err
+	}
+	return repository.PageOf(works, query)
+}
+
 func (inm *InMemoryRepository) Add(ctx context.Context, work *models.WorkItem) (*models.WorkItem, error) {
 	inm.Mu.Lock()
 	defer inm.Mu.Unlock()
 
-	uuid, err := uuid.NewV4()
-	if err != nil {
+	if err := work.ValidateStatus(); err != nil {
 		return nil, err
 	}
 
-	work.WorkId = uuid.String()
-	inm.Data[uuid.String()] = work
+	if work.Id.IsZero() {
+		work.Id = primitive.NewObjectID()
+	}
+	work.EndDate = work.EndTime()
+	inm.Data[work.Id.Hex()] = copyWork(work)
 
 	return work, nil
 }
 
+func (inm *InMemoryRepository) Delete(ctx context.Context, work *models.WorkItem) error {
+	inm.Mu.Lock()
+	defer inm.Mu.Unlock()
+
+	if _, ok := inm.Data[work.Id.Hex()]; !ok {
+		return repository.NewErrorNotFound(fmt.Sprintf("work document with id %s not found", work.Id.Hex()))
+	}
+	delete(inm.Data, work.Id.Hex())
+	return nil
+}
+
+// Update replaces the document with the same document id or inserts it, as upsert in mongo does.
 func (inm *InMemoryRepository) Update(ctx context.Context, work *models.WorkItem) (*models.WorkItem, error) {
 	inm.Mu.Lock()
 	defer inm.Mu.Unlock()
 
-	if _, ok := inm.Data[work.WorkId]; !ok {
-		return nil, repository.NewErrorNotFound(fmt.Sprintf("Work with id %s not found", work.WorkId))
+	if err := work.ValidateStatus(); err != nil {
+		return nil, err
+	}
+
+	if work.Id.IsZero() {
+		work.Id = primitive.NewObjectID()
 	}
-	inm.Data[work.WorkId] = work
+	work.EndDate = work.EndTime()
+	inm.Data[work.Id.Hex()] = copyWork(work)
 	return work, nil
 }
@@ -0,0 +1,100 @@
+package inmemoryrepository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/repository/repositorytest"
+	"workScheduler/internal/scheduler/models"
+)
+
+func TestContract(t *testing.T) {
+	repositorytest.RunContractTests(t, func(t *testing.T) repository.ReadWriteRepository {
+		return NewInmemoryRepository()
+	})
+}
+
+func TestSnapshotRestore(t *testing.T) {
+
+	t.Run("succees restore saved snapshot", func(t *testing.T) {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "works.snapshot")
+		testTime := time.Date(2023, 04, 19, 1, 0, 0, 0, time.UTC)
+
+		repo := NewInmemoryRepository()
+		for _, zone := range []string{"Zone_1", "Zone_2"} {
+			_, err := repo.Add(ctx, &models.WorkItem{
+				WorkId:          "1",
+				Zones:           []string{zone},
+				StartDate:       testTime,
+				DurationMinutes: 60,
+				Status:          "planned",
+				CompressionRate: 1,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error on add: %v", err)
+			}
+		}
+		repo.SaveZone(ctx, configuration.Zone{ZoneId: "Zone_3", Windows: []configuration.Window{{StartHour: 1, EndHour: 5}}})
+		repo.SaveConfig(ctx, "max_deadline_days: 7\n", 0)
+		repo.SaveConfig(ctx, "max_deadline_days: 14\n", 1)
+		if err := repo.Snapshot(path); err != nil {
+			t.Fatalf("unexpected error on snapshot: %v", err)
+		}
+
+		restored := NewInmemoryRepository()
+		if err := restored.Restore(path); err != nil {
+			t.Fatalf("unexpected error on restore: %v", err)
+		}
+		works, err := restored.GetById(ctx, "1")
+		if err != nil {
+			t.Fatalf("unexpected error on get: %v", err)
+		}
+		if len(works) != 2 {
+			t.Fatalf("unexpected restored works count: %v, want 2", len(works))
+		}
+		for _, work := range works {
+			if work.Id.IsZero() || !work.StartDate.Equal(testTime) || work.CompressionRate != 1 {
+				t.Errorf("unexpected restored work: %v", work)
+			}
+		}
+		if zones, _ := restored.ListZones(ctx); len(zones) != 1 || zones[0].ZoneId != "Zone_3" || len(zones[0].Windows) != 1 {
+			t.Errorf("unexpected restored zones: %v", zones)
+		}
+		if config, err := restored.GetConfig(ctx, 2); err != nil || config.Document != "max_deadline_days: 14\n" {
+			t.Errorf("unexpected restored config revision: %v %v", config, err)
+		}
+	})
+
+	t.Run("succees save snapshot on close", func(t *testing.T) {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "works.snapshot")
+
+		repo, err := NewInmemoryRepositoryWithSnapshots(ctx, path, time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error on create: %v", err)
+		}
+		repo.Add(ctx, &models.WorkItem{WorkId: "1", Zones: []string{"Zone_1"}, StartDate: time.Now(), DurationMinutes: 60, Status: "planned"})
+		if err := repo.Close(); err != nil {
+			t.Fatalf("unexpected error on close: %v", err)
+		}
+
+		restored := NewInmemoryRepository()
+		if err := restored.Restore(path); err != nil {
+			t.Fatalf("unexpected error on restore: %v", err)
+		}
+		if works, err := restored.GetById(ctx, "1"); err != nil || len(works) != 1 {
+			t.Errorf("unexpected restored works: %v %v", works, err)
+		}
+	})
+
+	t.Run("succees restore missing snapshot", func(t *testing.T) {
+		repo := NewInmemoryRepository()
+		if err := repo.Restore(filepath.Join(t.TempDir(), "missing.snapshot")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package inmemoryrepository
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"workScheduler/internal/repository"
+)
+
+var _ repository.LeaseRepository = (*InMemoryRepository)(nil)
+
+func (inm *InMemoryRepository) AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (repository.Lease, bool, error) {
+	inm.Mu.Lock()
+	defer inm.Mu.Unlock()
+
+	now := time.Now()
+	lease, ok := inm.Leases[name]
+	if !ok {
+		lease = &repository.Lease{Name: name}
+		inm.Leases[name] = lease
+	}
+	if lease.Holder != holder && lease.ExpiresAt.After(now) {
+		return *lease, false, nil
+	}
+	if lease.Holder != holder {
+		lease.Holder = holder
+		lease.Token++
+	}
+	lease.ExpiresAt = now.Add(ttl)
+	return *lease, true, nil
+}
+
+func (inm *InMemoryRepository) ReleaseLease(ctx context.Context, name string, holder string) error {
+	inm.Mu.Lock()
+	defer inm.Mu.Unlock()
+
+	if lease, ok := inm.Leases[name]; ok && lease.Holder == holder {
+		lease.ExpiresAt = time.Now()
+	}
+	return nil
+}
+
+func (inm *InMemoryRepository) VerifyLease(ctx context.Context, name string, token int64) error {
+	inm.Mu.Lock()
+	defer inm.Mu.Unlock()
+
+	lease, ok := inm.Leases[name]
+	if !ok || lease.Token != token || !lease.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("lease %s with token %d is lost", name, token)
+	}
+	return nil
+}
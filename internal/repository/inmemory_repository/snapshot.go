@@ -0,0 +1,225 @@
+package inmemoryrepository
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const defaultSnapshotInterval = 60 * time.Second
+
+// NewInmemoryRepositoryWithSnapshots restores the repository from path and saves it back
+// there every interval until ctx is done. Without path it is the same as NewInmemoryRepository.
+func NewInmemoryRepositoryWithSnapshots(ctx context.Context, path string, interval time.Duration) (inm *InMemoryRepository, err error) {
+	inm = NewInmemoryRepository()
+
+	if path == "" {
+		return
+	}
+	if interval <= 0 {
+		interval = defaultSnapshotInterval
+	}
+
+	if err = inm.Restore(path); err != nil {
+		inm = nil
+		return
+	}
+	inm.stopSnapshots = make(chan struct{})
+	inm.snapshotsDone = make(chan struct{})
+	go func() {
+		defer close(inm.snapshotsDone)
+		inm.RunSnapshots(ctx, path, interval, inm.stopSnapshots)
+	}()
+	return
+}
+
+// RunSnapshots periodically saves repository to path and makes the last snapshot when ctx is done
+// or stop is closed.
+func (inm *InMemoryRepository) RunSnapshots(ctx context.Context, path string, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			inm.lastSnapshot(path)
+			return
+		case <-stop:
+			inm.lastSnapshot(path)
+			return
+		case <-ticker.C:
+			if err := inm.Snapshot(path); err != nil {
+				log.Printf("WARNING: unable to save in-memory snapshot: %s\n", err)
+			}
+		}
+	}
+}
+
+func (inm *InMemoryRepository) lastSnapshot(path string) {
+	if err := inm.Snapshot(path); err != nil {
+		log.Printf("WARNING: unable to save in-memory snapshot on shutdown: %s\n", err)
+	}
+}
+
+// Close makes the last snapshot and stops snapshots, it returns after the snapshot is saved.
+func (inm *InMemoryRepository) Close() error {
+	if inm.stopSnapshots == nil {
+		return nil
+	}
+	inm.closeOnce.Do(func() { close(inm.stopSnapshots) })
+	<-inm.snapshotsDone
+	return nil
+}
+
+// snapshotRecord is a snapshot document of zone or config revision, documents without kind are
+// works, so snapshots of older versions are restored as is.
+type snapshotRecord struct {
+	Kind   string                     `bson:"kind"`
+	Zone   *configuration.Zone        `bson:"zone,omitempty"`
+	Config *repository.ConfigRevision `bson:"config,omitempty"`
+}
+
+const (
+	recordZone   = "zone"
+	recordConfig = "config"
+)
+
+// Snapshot atomically writes works, zones and config history to path as a sequence of bson
+// documents. Leases are not saved, they expire anyway while the process is down.
+func (inm *InMemoryRepository) Snapshot(path string) (err error) {
+	inm.Mu.Lock()
+	records := make([]interface{}, 0, len(inm.Data)+len(inm.Zones)+len(inm.Configs))
+	for _, work := range inm.Data {
+		records = append(records, work)
+	}
+	for _, zone := range inm.Zones {
+		zone := zone
+		records = append(records, snapshotRecord{Kind: recordZone, Zone: &zone})
+	}
+	for i := range inm.Configs {
+		records = append(records, snapshotRecord{Kind: recordConfig, Config: &inm.Configs[i]})
+	}
+	docs := make([][]byte, 0, len(records))
+	for _, record := range records {
+		doc, marshalErr := bson.Marshal(record)
+		if marshalErr != nil {
+			inm.Mu.Unlock()
+			return marshalErr
+		}
+		docs = append(docs, doc)
+	}
+	works := len(inm.Data)
+	inm.Mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	w := bufio.NewWriter(tmp)
+	for _, doc := range docs {
+		if _, err = w.Write(doc); err != nil {
+			return
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return
+	}
+	if err = tmp.Sync(); err != nil {
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return
+	}
+	log.Printf("successfully saved %d works to in-memory snapshot %s\n", works, path)
+	return
+}
+
+// Restore replaces repository data, zones and config history with snapshot in path, missing file
+// is not an error.
+func (inm *InMemoryRepository) Restore(path string) (err error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Printf("in-memory snapshot %s not found, starting with empty storage\n", path)
+		return nil
+	}
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	data := make(map[string]*models.WorkItem)
+	zones := make(map[string]configuration.Zone)
+	configs := []repository.ConfigRevision{}
+	r := bufio.NewReader(file)
+	for {
+		var size [4]byte
+		if _, err = io.ReadFull(r, size[:]); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("corrupted in-memory snapshot %s: %w", path, err)
+		}
+		docSize := binary.LittleEndian.Uint32(size[:])
+		if docSize < 5 {
+			return fmt.Errorf("corrupted in-memory snapshot %s: invalid document size %d", path, docSize)
+		}
+		doc := make([]byte, docSize)
+		copy(doc, size[:])
+		if _, err = io.ReadFull(r, doc[4:]); err != nil {
+			return fmt.Errorf("corrupted in-memory snapshot %s: %w", path, err)
+		}
+		if _, lookupErr := bson.Raw(doc).LookupErr("kind"); lookupErr == nil {
+			var record snapshotRecord
+			if err = bson.Unmarshal(doc, &record); err != nil {
+				return fmt.Errorf("corrupted in-memory snapshot %s: %w", path, err)
+			}
+			switch {
+			case record.Kind == recordZone && record.Zone != nil:
+				zones[record.Zone.ZoneId] = *record.Zone
+			case record.Kind == recordConfig && record.Config != nil:
+				configs = append(configs, *record.Config)
+			default:
+				return fmt.Errorf("corrupted in-memory snapshot %s: unknown record %s", path, record.Kind)
+			}
+			continue
+		}
+		var work models.WorkItem
+		if err = bson.Unmarshal(doc, &work); err != nil {
+			return fmt.Errorf("corrupted in-memory snapshot %s: %w", path, err)
+		}
+		data[work.Id.Hex()] = &work
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Revision < configs[j].Revision
+	})
+
+	inm.Mu.Lock()
+	inm.Data = data
+	inm.Zones = zones
+	inm.Configs = configs
+	inm.Mu.Unlock()
+	log.Printf("successfully restored %d works from in-memory snapshot %s\n", len(data), path)
+	return nil
+}
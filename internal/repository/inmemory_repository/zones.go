@@ -0,0 +1,33 @@
+package inmemoryrepository
+
+import (
+	"context"
+	"sort"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+)
+
+var _ repository.ZoneRepository = (*InMemoryRepository)(nil)
+
+func (inm *InMemoryRepository) ListZones(ctx context.Context) ([]configuration.Zone, error) {
+	inm.Mu.Lock()
+	defer inm.Mu.Unlock()
+
+	zones := []configuration.Zone{}
+	for _, zone := range inm.Zones {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].ZoneId < zones[j].ZoneId
+	})
+	return zones, nil
+}
+
+func (inm *InMemoryRepository) SaveZone(ctx context.Context, zone configuration.Zone) error {
+	inm.Mu.Lock()
+	defer inm.Mu.Unlock()
+
+	zone.Windows = append([]configuration.Window{}, zone.Windows...)
+	inm.Zones[zone.ZoneId] = zone
+	return nil
+}
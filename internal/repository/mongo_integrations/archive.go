@@ -0,0 +1,47 @@
+package mongo
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultArchiveCollection = "works_archive"
+
+// Archive copies works to archive collection keeping their document ids, so repeated archiving
+// after a failed delete does not duplicate them.
+func (m *MongoClient) Archive(ctx context.Context, works []*models.WorkItem) (err error) {
+	if len(works) == 0 {
+		return
+	}
+	var writes []mongo.WriteModel
+	for _, work := range works {
+		work.EndDate = work.EndTime()
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: work.Id}}).
+			SetReplacement(work).
+			SetUpsert(true))
+	}
+	out, err := m.archiveCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return
+	}
+	log.Printf("successfully archived %v work documents\n", out.UpsertedCount+out.ModifiedCount)
+	return
+}
+
+func (m *MongoClient) ListArchived(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string) (result []*models.WorkItem, err error) {
+	filter := bson.D{{Key: "$and", Value: listFilter(from, to, zones, statuses, repository.ListModeOverlaps)}}
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "startDate", Value: 1}})
+
+	log.Printf("searching for archived work documents with filer %+v\n", filter)
+	return findIn(ctx, m.archiveCollection, filter, findOptions)
+}
@@ -0,0 +1,50 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"workScheduler/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultConfigsCollection = "config_history"
+
+var _ repository.ConfigHistoryRepository = (*MongoClient)(nil)
+
+func (m *MongoClient) SaveConfig(ctx context.Context, document string, previous int64) (revision repository.ConfigRevision, err error) {
+	revision = repository.ConfigRevision{
+		Revision:  previous + 1,
+		Document:  document,
+		CreatedAt: time.Now(),
+	}
+	// revision is the document id, so only one of concurrent writers saves it
+	_, err = m.configsCollection.InsertOne(ctx, revision)
+	if mongo.IsDuplicateKeyError(err) {
+		err = repository.NewErrorConflict(fmt.Sprintf("config revision %d is already saved", revision.Revision))
+	}
+	return
+}
+
+func (m *MongoClient) ListConfigs(ctx context.Context) (revisions []repository.ConfigRevision, err error) {
+	cur, err := m.configsCollection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}))
+	if err != nil {
+		return
+	}
+	revisions = []repository.ConfigRevision{}
+	err = cur.All(ctx, &revisions)
+	return
+}
+
+func (m *MongoClient) GetConfig(ctx context.Context, revision int64) (result repository.ConfigRevision, err error) {
+	err = m.configsCollection.FindOne(ctx, bson.D{{Key: "_id", Value: revision}}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = repository.NewErrorNotFound(fmt.Sprintf("config revision %d not found", revision))
+	}
+	return
+}
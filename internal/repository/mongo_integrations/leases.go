@@ -0,0 +1,70 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"workScheduler/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultLeasesCollection = "leases"
+
+var _ repository.LeaseRepository = (*MongoClient)(nil)
+
+// AcquireLease compares expiration with server time ($$NOW), so replica clocks may differ.
+func (m *MongoClient) AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (lease repository.Lease, acquired bool, err error) {
+	filter := bson.D{
+		{Key: "_id", Value: name},
+		{Key: "$expr", Value: bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "$eq", Value: bson.A{"$holder", holder}}},
+			bson.D{{Key: "$lte", Value: bson.A{"$expiresAt", "$$NOW"}}},
+		}}}},
+	}
+	update := bson.A{bson.D{{Key: "$set", Value: bson.D{
+		{Key: "token", Value: bson.D{{Key: "$cond", Value: bson.A{
+			bson.D{{Key: "$eq", Value: bson.A{"$holder", holder}}},
+			"$token",
+			bson.D{{Key: "$add", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$token", 0}}}, 1}}},
+		}}}},
+		{Key: "holder", Value: holder},
+		{Key: "expiresAt", Value: bson.D{{Key: "$add", Value: bson.A{"$$NOW", ttl.Milliseconds()}}}},
+	}}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	err = m.leasesCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&lease)
+	if mongo.IsDuplicateKeyError(err) {
+		// lease document exists and is held by another holder
+		return lease, false, nil
+	}
+	if err != nil {
+		return
+	}
+	acquired = true
+	return
+}
+
+func (m *MongoClient) ReleaseLease(ctx context.Context, name string, holder string) (err error) {
+	filter := bson.D{{Key: "_id", Value: name}, {Key: "holder", Value: holder}}
+	update := bson.A{bson.D{{Key: "$set", Value: bson.D{{Key: "expiresAt", Value: "$$NOW"}}}}}
+	_, err = m.leasesCollection.UpdateOne(ctx, filter, update)
+	return
+}
+
+func (m *MongoClient) VerifyLease(ctx context.Context, name string, token int64) (err error) {
+	filter := bson.D{
+		{Key: "_id", Value: name},
+		{Key: "token", Value: token},
+		{Key: "$expr", Value: bson.D{{Key: "$gt", Value: bson.A{"$expiresAt", "$$NOW"}}}},
+	}
+	err = m.leasesCollection.FindOne(ctx, filter).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = fmt.Errorf("lease %s with token %d is lost", name, token)
+	}
+	return
+}
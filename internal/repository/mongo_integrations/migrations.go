@@ -0,0 +1,227 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/exp/slices"
+)
+
+const defaultMigrationsCollection = "migrations"
+
+// requiredIndexes must exist on works collection, List and GetById rely on them
+var requiredIndexes = []string{"workId", "startDate_zones_status_compound", "endDate_startDate_status_compound"}
+
+// migration is applied once and recorded in migrations collection by version. Replicas may
+// apply the same migration concurrently on startup, so Up must be idempotent.
+type migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, m *MongoClient) error
+}
+
+type migrationRecord struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"appliedAt"`
+}
+
+var migrations = []migration{
+	{
+		Version: 1,
+		Name:    "create_works_collection",
+		Up: func(ctx context.Context, m *MongoClient) error {
+			works := m.worksCollection
+			names, err := works.Database().ListCollectionNames(ctx, bson.D{{Key: "name", Value: works.Name()}})
+			if err != nil || len(names) > 0 {
+				return err
+			}
+			// other replica may create the collection after the check
+			err = works.Database().CreateCollection(ctx, works.Name())
+			var cmdErr mongo.CommandError
+			if errors.As(err, &cmdErr) && cmdErr.Code == 48 { // NamespaceExists
+				return nil
+			}
+			return err
+		},
+	},
+	{
+		Version: 2,
+		Name:    "create_works_indexes",
+		Up: func(ctx context.Context, m *MongoClient) error {
+			works := m.worksCollection
+			_, err := works.Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "workId", Value: 1}},
+					Options: options.Index().SetName("workId"),
+				},
+				{
+					Keys:    bson.D{{Key: "startDate", Value: 1}, {Key: "zones", Value: 1}, {Key: "status", Value: 1}},
+					Options: options.Index().SetName("startDate_zones_status_compound"),
+				},
+				{
+					Keys:    bson.D{{Key: "endDate", Value: 1}, {Key: "startDate", Value: 1}, {Key: "status", Value: 1}},
+					Options: options.Index().SetName("endDate_startDate_status_compound"),
+				},
+			})
+			return err
+		},
+	},
+	{
+		Version: 3,
+		Name:    "backfill_works_end_date",
+		Up: func(ctx context.Context, m *MongoClient) error {
+			works := m.worksCollection
+			filter := bson.D{{Key: "endDate", Value: bson.D{{Key: "$exists", Value: false}}}}
+			update := bson.A{bson.D{{Key: "$set", Value: bson.D{{Key: "endDate", Value: bson.D{{Key: "$add", Value: bson.A{
+				"$startDate",
+				bson.D{{Key: "$multiply", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$durationMinutes", 0}}}, 60000}}},
+			}}}}}}}}
+			_, err := works.UpdateMany(ctx, filter, update)
+			return err
+		},
+	},
+	{
+		// index on not existing "zone" field and unused zones collection were created by former js scripts
+		Version: 4,
+		Name:    "drop_zone_index_and_zones_collection",
+		Up: func(ctx context.Context, m *MongoClient) error {
+			works := m.worksCollection
+			_, err := works.Indexes().DropOne(ctx, "startDate_zone_status_compound")
+			var cmdErr mongo.CommandError
+			if err != nil && !(errors.As(err, &cmdErr) && cmdErr.Code == 27) { // IndexNotFound
+				return err
+			}
+			return works.Database().Collection("zones").Drop(ctx)
+		},
+	},
+	{
+		Version: 5,
+		Name:    "create_works_archive_indexes",
+		Up: func(ctx context.Context, m *MongoClient) error {
+			_, err := m.archiveCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "startDate", Value: 1}, {Key: "zones", Value: 1}, {Key: "status", Value: 1}},
+					Options: options.Index().SetName("startDate_zones_status_compound"),
+				},
+				{
+					Keys:    bson.D{{Key: "endDate", Value: 1}, {Key: "startDate", Value: 1}, {Key: "status", Value: 1}},
+					Options: options.Index().SetName("endDate_startDate_status_compound"),
+				},
+			})
+			return err
+		},
+	},
+	{
+		// statuses added later need a new migration replacing the validator, lists of applied
+		// migrations must not change
+		Version: 6,
+		Name:    "add_works_status_validator",
+		Up: func(ctx context.Context, m *MongoClient) error {
+			return m.worksCollection.Database().RunCommand(ctx, bson.D{
+				{Key: "collMod", Value: m.worksCollection.Name()},
+				{Key: "validator", Value: worksValidator([]string{"planned", "in_progress", "overdue", "completed", "canceled", "failed", "rolled_back", "aborted"})},
+				// existing invalid documents may still be updated, new writes are checked
+				{Key: "validationLevel", Value: "moderate"},
+				{Key: "validationAction", Value: "error"},
+			}).Err()
+		},
+	},
+	{
+		Version: 7,
+		Name:    "add_held_status_to_works_validator",
+		Up: func(ctx context.Context, m *MongoClient) error {
+			return m.worksCollection.Database().RunCommand(ctx, bson.D{
+				{Key: "collMod", Value: m.worksCollection.Name()},
+				{Key: "validator", Value: worksValidator([]string{"held", "planned", "in_progress", "overdue", "completed", "canceled", "failed", "rolled_back", "aborted"})},
+			}).Err()
+		},
+	},
+	{
+		// zones collection dropped in migration 4 is used for zones managed through API
+		Version: 8,
+		Name:    "create_zones_collection",
+		Up: func(ctx context.Context, m *MongoClient) error {
+			_, err := m.zonesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "zoneId", Value: 1}},
+				Options: options.Index().SetName("zoneId_unique").SetUnique(true),
+			})
+			return err
+		},
+	},
+}
+
+// worksValidator allows only the given statuses of works.
+func worksValidator(statuses []string) bson.D {
+	return bson.D{{Key: "$jsonSchema", Value: bson.D{
+		{Key: "bsonType", Value: "object"},
+		{Key: "properties", Value: bson.D{
+			{Key: "status", Value: bson.D{{Key: "enum", Value: statuses}}},
+		}},
+	}}}
+}
+
+// Migrate applies all not yet applied migrations.
+func (m *MongoClient) Migrate(ctx context.Context) (err error) {
+	cursor, err := m.migrationsCollection.Find(ctx, bson.D{})
+	if err != nil {
+		return
+	}
+	var records []migrationRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return
+	}
+	applied := make(map[int]bool)
+	for _, record := range records {
+		applied[record.Version] = true
+	}
+
+	for _, migration := range migrations {
+		if applied[migration.Version] {
+			continue
+		}
+		if err = migration.Up(ctx, m); err != nil {
+			err = fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			return
+		}
+		_, err = m.migrationsCollection.InsertOne(ctx, migrationRecord{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now().UTC(),
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			// applied by another replica at the same time
+			err = nil
+		}
+		if err != nil {
+			return
+		}
+		log.Printf("successfully applied mongo migration %d_%s\n", migration.Version, migration.Name)
+	}
+	return
+}
+
+// VerifySchema checks that required indexes exist on works collection.
+func (m *MongoClient) VerifySchema(ctx context.Context) (err error) {
+	specs, err := m.worksCollection.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return
+	}
+	var names []string
+	for _, spec := range specs {
+		names = append(names, spec.Name)
+	}
+	for _, index := range requiredIndexes {
+		if !slices.Contains(names, index) {
+			err = fmt.Errorf("required index %s is missing on %s collection, run migrations", index, m.worksCollection.Name())
+			return
+		}
+	}
+	return
+}
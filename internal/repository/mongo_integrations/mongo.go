@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
-	"strconv"
 	"time"
 
 	"workScheduler/internal/repository"
@@ -18,38 +16,85 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
+// sortValueField holds the value works are paged by, missing deadline is sorted as zero time same
+// as in repository.SortValue
+const sortValueField = "sortValue"
+
+var sortExpressions = map[repository.SortField]interface{}{
+	repository.SortByStartDate: "$startDate",
+	repository.SortByEndDate:   "$endDate",
+	repository.SortByDeadline:  bson.D{{Key: "$ifNull", Value: bson.A{"$deadline", time.Time{}}}},
+}
+
 type MongoClient struct {
-	client          *mongo.Client
-	worksCollection *mongo.Collection
+	client               *mongo.Client
+	worksCollection      *mongo.Collection
+	migrationsCollection *mongo.Collection
+	archiveCollection    *mongo.Collection
+	leasesCollection     *mongo.Collection
+	configsCollection    *mongo.Collection
+	zonesCollection      *mongo.Collection
+}
+
+// Options are mongo connection settings, empty collections get default names.
+type Options struct {
+	URI                  string
+	Database             string
+	WorksCollection      string
+	MigrationsCollection string
+	ArchiveCollection    string
+	LeasesCollection     string
+	ConfigsCollection    string
+	ZonesCollection      string
+	RetryWrites          bool
+	Timeout              time.Duration
 }
 
-func NewMongoClient(ctx context.Context) (c *MongoClient, err error) {
-	uri := os.Getenv("MONGO_URI")
+func NewMongoClient(ctx context.Context, o Options) (c *MongoClient, err error) {
+	uri := o.URI
 	if uri == "" {
-		err = fmt.Errorf("empty MONGO_URI for connection string")
+		err = fmt.Errorf("empty mongo uri for connection string")
 		return
 	}
-	databaseName := os.Getenv("MONGO_DATABASE")
+	databaseName := o.Database
 	if databaseName == "" {
-		err = fmt.Errorf("empty MONGO_DATABASE for connection string")
+		err = fmt.Errorf("empty mongo database for connection string")
 		return
 	}
-	collectionName := os.Getenv("MONGO_WORKS_COLLECTION")
+	collectionName := o.WorksCollection
 	if collectionName == "" {
-		err = fmt.Errorf("empty MONGO_WORKS_COLLECTION for connection string")
+		err = fmt.Errorf("empty mongo works collection for connection string")
 		return
 	}
+	migrationsCollectionName := o.MigrationsCollection
+	if migrationsCollectionName == "" {
+		migrationsCollectionName = defaultMigrationsCollection
+	}
+	archiveCollectionName := o.ArchiveCollection
+	if archiveCollectionName == "" {
+		archiveCollectionName = defaultArchiveCollection
+	}
+	leasesCollectionName := o.LeasesCollection
+	if leasesCollectionName == "" {
+		leasesCollectionName = defaultLeasesCollection
+	}
+	configsCollectionName := o.ConfigsCollection
+	if configsCollectionName == "" {
+		configsCollectionName = defaultConfigsCollection
+	}
+	zonesCollectionName := o.ZonesCollection
+	if zonesCollectionName == "" {
+		zonesCollectionName = defaultZonesCollection
+	}
 
 	opts := options.Client().ApplyURI(uri).
 		SetWriteConcern(writeconcern.New(writeconcern.WMajority()))
 
-	retryWrites := os.Getenv("MONGO_RETRY_WRITES")
-	if retries, err := strconv.ParseBool(retryWrites); err == nil && retries {
-		opts.SetRetryWrites(retries)
+	if o.RetryWrites {
+		opts.SetRetryWrites(o.RetryWrites)
 	}
-	timeoutMs := os.Getenv("MONGO_TIMEOUT_MS")
-	if timeout, err := strconv.ParseInt(timeoutMs, 10, 32); err == nil && timeout != 0 {
-		opts.SetTimeout(time.Duration(timeout) * time.Millisecond)
+	if o.Timeout != 0 {
+		opts.SetTimeout(o.Timeout)
 	}
 
 	c = &MongoClient{}
@@ -60,14 +105,25 @@ func NewMongoClient(ctx context.Context) (c *MongoClient, err error) {
 	}
 
 	c.worksCollection = c.client.Database(databaseName).Collection(collectionName)
+	c.migrationsCollection = c.client.Database(databaseName).Collection(migrationsCollectionName)
+	c.archiveCollection = c.client.Database(databaseName).Collection(archiveCollectionName)
+	c.leasesCollection = c.client.Database(databaseName).Collection(leasesCollectionName)
+	c.configsCollection = c.client.Database(databaseName).Collection(configsCollectionName)
+	c.zonesCollection = c.client.Database(databaseName).Collection(zonesCollectionName)
 	return
 }
 
 var _ repository.ReadWriteRepository = (*MongoClient)(nil)
+var _ repository.Migrator = (*MongoClient)(nil)
+var _ repository.Archive = (*MongoClient)(nil)
 
 func (m *MongoClient) Add(ctx context.Context, work *models.WorkItem) (result *models.WorkItem, err error) {
 
 	result = work
+	if work.Id.IsZero() {
+		work.Id = primitive.NewObjectID()
+	}
+	work.EndDate = work.EndTime()
 	out, err := m.worksCollection.InsertOne(ctx, work)
 	if err != nil {
 		return
@@ -81,6 +137,10 @@ func (m *MongoClient) Add(ctx context.Context, work *models.WorkItem) (result *m
 }
 
 func (m *MongoClient) Update(ctx context.Context, work *models.WorkItem) (result *models.WorkItem, err error) {
+	if work.Id.IsZero() {
+		work.Id = primitive.NewObjectID()
+	}
+	work.EndDate = work.EndTime()
 	filter := bson.D{{Key: "_id", Value: work.Id}}
 	update := bson.M{
 		"$set": work,
@@ -95,31 +155,115 @@ func (m *MongoClient) Update(ctx context.Context, work *models.WorkItem) (result
 	return
 }
 
+func (m *MongoClient) Delete(ctx context.Context, work *models.WorkItem) (err error) {
+	filter := bson.D{{Key: "_id", Value: work.Id}}
+	out, err := m.worksCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		return
+	}
+	if out.DeletedCount == 0 {
+		return repository.NewErrorNotFound(fmt.Sprintf("work document with id %s not found", work.Id.Hex()))
+	}
+	log.Printf("successfully deleted work document with id %v\n", work.Id)
+	return
+}
+
 func (m *MongoClient) GetById(ctx context.Context, id string) (results []*models.WorkItem, err error) {
 	filter := bson.D{{Key: "workId", Value: id}}
-	cursor, err := m.worksCollection.Find(ctx, filter)
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "startDate", Value: 1}})
+	results, err = m.find(ctx, filter, findOptions)
+	if err == nil && len(results) == 0 {
+		err = repository.NewErrorNotFound(fmt.Sprintf("work with id %s not found", id))
+	}
+	return
+}
+
+func (m *MongoClient) List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string, mode repository.ListMode) (result []*models.WorkItem, err error) {
+	filter := bson.D{{Key: "$and", Value: listFilter(from, to, zones, statuses, mode)}}
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "startDate", Value: 1}})
+
+	log.Printf("searching for work documents with filer %+v\n", filter)
+	return m.find(ctx, filter, findOptions)
+}
+
+func (m *MongoClient) ListPage(ctx context.Context, query repository.PageQuery) (page repository.Page, err error) {
+	cursor, err := query.Normalize()
 	if err != nil {
 		return
 	}
-	defer cursor.Close(ctx)
+	direction, compare := 1, "$gt"
+	if query.SortOrder == repository.SortDesc {
+		direction, compare = -1, "$lt"
+	}
+	sortKey := string(query.SortBy)
 
-	for cursor.Next(ctx) {
-		var wi models.WorkItem
-		if err = cursor.Decode(&wi); err != nil {
-			return
+	// works are sorted by computed value, so documents without deadline are paged as zero time
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "$and", Value: listFilter(query.From, query.To, query.Zones, query.Statuses, query.Mode)}}}},
+		{{Key: "$addFields", Value: bson.D{{Key: sortValueField, Value: sortExpressions[query.SortBy]}}}},
+	}
+	if cursor != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: sortValueField, Value: bson.D{{Key: compare, Value: cursor.Value}}}},
+			bson.D{
+				{Key: sortValueField, Value: cursor.Value},
+				{Key: "_id", Value: bson.D{{Key: compare, Value: cursor.Id}}},
+			},
+		}}}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: sortValueField, Value: direction}, {Key: "_id", Value: direction}}}},
+		// one more document to know if there is a next page
+		bson.D{{Key: "$limit", Value: int64(query.Limit + 1)}},
+	)
+	if len(query.Fields) > 0 {
+		projection := bson.D{{Key: "_id", Value: 1}, {Key: sortKey, Value: 1}}
+		if query.SortBy == repository.SortByEndDate {
+			projection = append(projection, bson.E{Key: "startDate", Value: 1}, bson.E{Key: "durationMinutes", Value: 1})
+		}
+		for _, f := range query.Fields {
+			projection = append(projection, bson.E{Key: f, Value: 1})
 		}
-		results = append(results, &wi)
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: projection}})
+	} else {
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: bson.D{{Key: sortValueField, Value: 0}}}})
 	}
-	if err = cursor.Err(); err != nil {
+
+	log.Printf("searching for work documents page with pipeline %+v\n", pipeline)
+	works, err := m.aggregate(ctx, pipeline)
+	if err != nil {
 		return
 	}
+	if len(works) > query.Limit {
+		works = works[:query.Limit]
+		page.NextCursor = repository.NextCursor(works[len(works)-1], query)
+	}
+	for _, work := range works {
+		page.Works = append(page.Works, repository.Project(work, query.Fields))
+	}
 	return
 }
 
-func (m *MongoClient) List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string) (result []*models.WorkItem, err error) {
-	orderedFilter := bson.A{
-		bson.D{{Key: "startDate", Value: bson.D{{Key: "$gte", Value: from}}}},
-		bson.D{{Key: "startDate", Value: bson.D{{Key: "$lte", Value: to}}}},
+func listFilter(from time.Time, to time.Time, zones []string, statuses []string, mode repository.ListMode) bson.A {
+	var orderedFilter bson.A
+	switch mode {
+	case repository.ListModeStartsIn:
+		orderedFilter = bson.A{
+			bson.D{{Key: "startDate", Value: bson.D{{Key: "$gte", Value: from}}}},
+			bson.D{{Key: "startDate", Value: bson.D{{Key: "$lte", Value: to}}}},
+		}
+	case repository.ListModeEndsIn:
+		orderedFilter = bson.A{
+			bson.D{{Key: "endDate", Value: bson.D{{Key: "$gte", Value: from}}}},
+			bson.D{{Key: "endDate", Value: bson.D{{Key: "$lte", Value: to}}}},
+		}
+	default:
+		orderedFilter = bson.A{
+			bson.D{{Key: "startDate", Value: bson.D{{Key: "$lte", Value: to}}}},
+			bson.D{{Key: "endDate", Value: bson.D{{Key: "$gt", Value: from}}}},
+		}
 	}
 	if len(zones) > 0 {
 		orderedFilter = append(orderedFilter, bson.D{{Key: "zones", Value: bson.D{{Key: "$in", Value: zones}}}})
@@ -127,15 +271,30 @@ func (m *MongoClient) List(ctx context.Context, from time.Time, to time.Time, zo
 	if len(statuses) > 0 {
 		orderedFilter = append(orderedFilter, bson.D{{Key: "status", Value: bson.D{{Key: "$in", Value: statuses}}}})
 	}
-	filter := bson.D{{Key: "$and", Value: orderedFilter}}
-	findOptions := options.Find()
-	findOptions.SetSort(bson.D{{Key: "startDate", Value: 1}})
+	return orderedFilter
+}
 
-	log.Printf("searching for work documents with filer %+v\n", filter)
-	cursor, err := m.worksCollection.Find(ctx, filter, findOptions)
+func (m *MongoClient) find(ctx context.Context, filter bson.D, findOptions *options.FindOptions) (result []*models.WorkItem, err error) {
+	return findIn(ctx, m.worksCollection, filter, findOptions)
+}
+
+func (m *MongoClient) aggregate(ctx context.Context, pipeline mongo.Pipeline) (result []*models.WorkItem, err error) {
+	cursor, err := m.worksCollection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return
 	}
+	return decodeWorks(ctx, cursor)
+}
+
+func findIn(ctx context.Context, collection *mongo.Collection, filter bson.D, findOptions *options.FindOptions) (result []*models.WorkItem, err error) {
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return
+	}
+	return decodeWorks(ctx, cursor)
+}
+
+func decodeWorks(ctx context.Context, cursor *mongo.Cursor) (result []*models.WorkItem, err error) {
 	defer cursor.Close(ctx)
 
 	for cursor.Next(ctx) {
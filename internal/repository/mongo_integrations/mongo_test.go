@@ -0,0 +1,41 @@
+package mongo
+
+import (
+	"context"
+	"os"
+	"testing"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/repository/repositorytest"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestContract runs against a real server, e.g. started with `make test-integration`,
+// each repository gets its own database.
+func TestContract(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+	repositorytest.RunContractTests(t, func(t *testing.T) repository.ReadWriteRepository {
+		repo, err := NewMongoClient(context.Background(), Options{
+			URI:             uri,
+			Database:        "workScheduler_test_" + primitive.NewObjectID().Hex(),
+			WorksCollection: "works",
+		})
+		if err != nil {
+			t.Fatalf("unable to connect to mongo: %v", err)
+		}
+		if err := repo.Migrate(context.Background()); err != nil {
+			t.Fatalf("unable to migrate mongo: %v", err)
+		}
+		if err := repo.VerifySchema(context.Background()); err != nil {
+			t.Fatalf("unexpected schema after migrations: %v", err)
+		}
+		t.Cleanup(func() {
+			repo.worksCollection.Database().Drop(context.Background())
+			repo.client.Disconnect(context.Background())
+		})
+		return repo
+	})
+}
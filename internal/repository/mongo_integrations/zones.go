@@ -0,0 +1,30 @@
+package mongo
+
+import (
+	"context"
+
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultZonesCollection = "zones"
+
+var _ repository.ZoneRepository = (*MongoClient)(nil)
+
+func (m *MongoClient) ListZones(ctx context.Context) (zones []configuration.Zone, err error) {
+	cur, err := m.zonesCollection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "zoneId", Value: 1}}))
+	if err != nil {
+		return
+	}
+	zones = []configuration.Zone{}
+	err = cur.All(ctx, &zones)
+	return
+}
+
+func (m *MongoClient) SaveZone(ctx context.Context, zone configuration.Zone) (err error) {
+	_, err = m.zonesCollection.ReplaceOne(ctx, bson.D{{Key: "zoneId", Value: zone.ZoneId}}, zone, options.Replace().SetUpsert(true))
+	return
+}
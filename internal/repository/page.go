@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+	"workScheduler/internal/scheduler/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+type SortField string
+
+const (
+	SortByStartDate SortField = "startDate"
+	SortByEndDate   SortField = "endDate"
+	SortByDeadline  SortField = "deadline"
+)
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// ProjectableFields are work fields (by their json/bson names) which may be requested in PageQuery.Fields.
+var ProjectableFields = []string{"workId", "zones", "startDate", "durationMinutes", "deadline", "status", "workType", "priority", "reason", "team"}
+
+type PageQuery struct {
+	From      time.Time
+	To        time.Time
+	Zones     []string
+	Statuses  []string
+	Mode      ListMode
+	SortBy    SortField
+	SortOrder SortOrder
+	Limit     int
+	// Cursor is an opaque value from Page.NextCursor of the previous page, empty for the first page
+	Cursor string
+	// Fields limits returned work fields, empty means all fields
+	Fields []string
+}
+
+type Page struct {
+	Works []*models.WorkItem
+	// NextCursor is empty on the last page
+	NextCursor string
+}
+
+// Cursor is a position after the last returned work: its sort key and document id.
+type Cursor struct {
+	SortBy    SortField          `json:"s"`
+	SortOrder SortOrder          `json:"o"`
+	Value     time.Time          `json:"v"`
+	Id        primitive.ObjectID `json:"i"`
+}
+
+// Normalize fills defaults and validates the query, cursor is decoded and returned separately.
+func (q *PageQuery) Normalize() (cursor *Cursor, err error) {
+	if q.Mode == "" {
+		q.Mode = ListModeOverlaps
+	}
+	if _, err = ParseListMode(string(q.Mode)); err != nil {
+		return
+	}
+	if q.SortBy == "" {
+		q.SortBy = SortByStartDate
+	}
+	if q.SortBy != SortByStartDate && q.SortBy != SortByEndDate && q.SortBy != SortByDeadline {
+		err = fmt.Errorf("unknown sort field %q, expected one of: %s, %s, %s", q.SortBy, SortByStartDate, SortByEndDate, SortByDeadline)
+		return
+	}
+	if q.SortOrder == "" {
+		q.SortOrder = SortAsc
+	}
+	if q.SortOrder != SortAsc && q.SortOrder != SortDesc {
+		err = fmt.Errorf("unknown sort order %q, expected one of: %s, %s", q.SortOrder, SortAsc, SortDesc)
+		return
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageLimit
+	}
+	if q.Limit < 0 || q.Limit > MaxPageLimit {
+		err = fmt.Errorf("limit must be in range from 1 to %d, got %d", MaxPageLimit, q.Limit)
+		return
+	}
+	for _, f := range q.Fields {
+		if !slices.Contains(ProjectableFields, f) {
+			err = fmt.Errorf("unknown field %q, expected some of: %v", f, ProjectableFields)
+			return
+		}
+	}
+	if q.Cursor == "" {
+		return
+	}
+	cursor, err = DecodeCursor(q.Cursor)
+	if err != nil {
+		return
+	}
+	if cursor.SortBy != q.SortBy || cursor.SortOrder != q.SortOrder {
+		err = fmt.Errorf("cursor was issued for another sort options, got sort by %s %s", q.SortBy, q.SortOrder)
+		cursor = nil
+	}
+	return
+}
+
+func EncodeCursor(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	c := &Cursor{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return c, nil
+}
+
+// SortValue returns value of the field works are sorted by.
+func SortValue(work *models.WorkItem, field SortField) time.Time {
+	switch field {
+	case SortByEndDate:
+		return work.EndTime()
+	case SortByDeadline:
+		return work.Deadline
+	default:
+		return work.StartDate
+	}
+}
+
+// NextCursor returns cursor pointing after the work.
+func NextCursor(work *models.WorkItem, q PageQuery) string {
+	return EncodeCursor(Cursor{
+		SortBy:    q.SortBy,
+		SortOrder: q.SortOrder,
+		Value:     SortValue(work, q.SortBy),
+		Id:        work.Id,
+	})
+}
+
+// PageOf builds a page from already filtered works, for repositories without native pagination.
+func PageOf(works []*models.WorkItem, q PageQuery) (page Page, err error) {
+	cursor, err := q.Normalize()
+	if err != nil {
+		return
+	}
+	less := func(a *models.WorkItem, b *models.WorkItem) bool {
+		va, vb := SortValue(a, q.SortBy), SortValue(b, q.SortBy)
+		if !va.Equal(vb) {
+			return va.Before(vb)
+		}
+		return a.Id.Hex() < b.Id.Hex()
+	}
+	sort.Slice(works, func(i, j int) bool {
+		if q.SortOrder == SortDesc {
+			return less(works[j], works[i])
+		}
+		return less(works[i], works[j])
+	})
+
+	start := 0
+	if cursor != nil {
+		last := &models.WorkItem{Id: cursor.Id}
+		setSortValue(last, q.SortBy, cursor.Value)
+		start = sort.Search(len(works), func(i int) bool {
+			if q.SortOrder == SortDesc {
+				return less(works[i], last)
+			}
+			return less(last, works[i])
+		})
+	}
+	end := start + q.Limit
+	if end < len(works) {
+		page.NextCursor = NextCursor(works[end-1], q)
+	} else {
+		end = len(works)
+	}
+	for _, work := range works[start:end] {
+		page.Works = append(page.Works, Project(work, q.Fields))
+	}
+	return
+}
+
+func setSortValue(work *models.WorkItem, field SortField, value time.Time) {
+	switch field {
+	case SortByEndDate:
+		// EndTime is calculated from start and duration
+		work.StartDate = value
+		work.DurationMinutes = 0
+	case SortByDeadline:
+		work.Deadline = value
+	default:
+		work.StartDate = value
+	}
+}
+
+// Project returns copy of work with only given fields and document id, empty fields means all fields.
+func Project(work *models.WorkItem, fields []string) *models.WorkItem {
+	if len(fields) == 0 {
+		return work
+	}
+	p := &models.WorkItem{Id: work.Id}
+	for _, f := range fields {
+		switch f {
+		case "workId":
+			p.WorkId = work.WorkId
+		case "zones":
+			p.Zones = work.Zones
+		case "startDate":
+			p.StartDate = work.StartDate
+		case "durationMinutes":
+			p.DurationMinutes = work.DurationMinutes
+		case "deadline":
+			p.Deadline = work.Deadline
+		case "status":
+			p.Status = work.Status
+		case "workType":
+			p.WorkType = work.WorkType
+		case "priority":
+			p.Priority = work.Priority
+		case "reason":
+			p.Reason = work.Reason
+		case "team":
+			p.Team = work.Team
+		}
+	}
+	return p
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+)
+
+var _ repository.Archive = (*PostgresClient)(nil)
+
+// Archive copies works to works_archive table keeping their ids, so repeated archiving
+// after a failed delete does not duplicate them.
+func (p *PostgresClient) Archive(ctx context.Context, works []*models.WorkItem) (err error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var archived int64
+	for _, work := range works {
+		work.EndDate = work.EndTime()
+		out, err := tx.ExecContext(ctx, `INSERT INTO works_archive (`+workColumns+`, end_date)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+ON CONFLICT (id) DO NOTHING`, workArgs(work)...)
+		if err != nil {
+			return err
+		}
+		affected, _ := out.RowsAffected()
+		archived += affected
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	log.Printf("successfully archived %v work rows\n", archived)
+	return
+}
+
+func (p *PostgresClient) ListArchived(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string) (result []*models.WorkItem, err error) {
+	where, args := listCondition(from, to, zones, statuses, repository.ListModeOverlaps)
+	query := `SELECT ` + workColumns + ` FROM works_archive WHERE ` + where + ` ORDER BY start_date`
+
+	log.Printf("searching for archived work rows with query %s %v\n", query, args)
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+	return scanWorks(rows)
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"workScheduler/internal/repository"
+)
+
+var _ repository.ConfigHistoryRepository = (*PostgresClient)(nil)
+
+func (p *PostgresClient) SaveConfig(ctx context.Context, document string, previous int64) (revision repository.ConfigRevision, err error) {
+	revision.Revision = previous + 1
+	revision.Document = document
+	revision.CreatedAt = time.Now()
+	// revision is the primary key, so only one of concurrent writers saves it
+	result, err := p.db.ExecContext(ctx, `INSERT INTO config_history (revision, document, created_at) VALUES ($1, $2, $3) ON CONFLICT (revision) DO NOTHING`,
+		revision.Revision, revision.Document, revision.CreatedAt)
+	if err != nil {
+		return
+	}
+	if saved, err := result.RowsAffected(); err == nil && saved == 0 {
+		return revision, repository.NewErrorConflict(fmt.Sprintf("config revision %d is already saved", revision.Revision))
+	}
+	return
+}
+
+func (p *PostgresClient) ListConfigs(ctx context.Context) (revisions []repository.ConfigRevision, err error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT revision, document, created_at FROM config_history ORDER BY revision DESC`)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	revisions = []repository.ConfigRevision{}
+	for rows.Next() {
+		var revision repository.ConfigRevision
+		if err = rows.Scan(&revision.Revision, &revision.Document, &revision.CreatedAt); err != nil {
+			return
+		}
+		revisions = append(revisions, revision)
+	}
+	err = rows.Err()
+	return
+}
+
+func (p *PostgresClient) GetConfig(ctx context.Context, revision int64) (result repository.ConfigRevision, err error) {
+	err = p.db.QueryRowContext(ctx, `SELECT revision, document, created_at FROM config_history WHERE revision = $1`, revision).
+		Scan(&result.Revision, &result.Document, &result.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = repository.NewErrorNotFound(fmt.Sprintf("config revision %d not found", revision))
+	}
+	return
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"workScheduler/internal/repository"
+)
+
+var _ repository.LeaseRepository = (*PostgresClient)(nil)
+
+// AcquireLease compares expiration with database time, so replica clocks may differ.
+func (p *PostgresClient) AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (lease repository.Lease, acquired bool, err error) {
+	err = p.db.QueryRowContext(ctx, `INSERT INTO leases (name, holder, token, expires_at)
+VALUES ($1, $2, 1, now() + $3 * interval '1 millisecond')
+ON CONFLICT (name) DO UPDATE SET
+	token = CASE WHEN leases.holder = EXCLUDED.holder THEN leases.token ELSE leases.token + 1 END,
+	holder = EXCLUDED.holder,
+	expires_at = EXCLUDED.expires_at
+WHERE leases.holder = EXCLUDED.holder OR leases.expires_at <= now()
+RETURNING name, holder, token, expires_at`, name, holder, ttl.Milliseconds()).Scan(&lease.Name, &lease.Holder, &lease.Token, &lease.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// lease is held by another holder
+		return lease, false, nil
+	}
+	if err != nil {
+		return
+	}
+	acquired = true
+	return
+}
+
+func (p *PostgresClient) ReleaseLease(ctx context.Context, name string, holder string) (err error) {
+	_, err = p.db.ExecContext(ctx, `UPDATE leases SET expires_at = now() WHERE name = $1 AND holder = $2`, name, holder)
+	return
+}
+
+func (p *PostgresClient) VerifyLease(ctx context.Context, name string, token int64) (err error) {
+	var valid bool
+	err = p.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM leases WHERE name = $1 AND token = $2 AND expires_at > now())`, name, token).Scan(&valid)
+	if err == nil && !valid {
+		err = fmt.Errorf("lease %s with token %d is lost", name, token)
+	}
+	return
+}
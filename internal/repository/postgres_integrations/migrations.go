@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// advisory lock key, so only one replica applies migrations at a time
+const migrationsLockKey = 7_345_002_026
+
+type migration struct {
+	Version int
+	Name    string
+	Query   string
+}
+
+func loadMigrations() (migrations []migration, err error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, _, found := strings.Cut(name, "_")
+		if !found {
+			err = fmt.Errorf("migration file %s must be named as <version>_<name>.sql", name)
+			return
+		}
+		version, convErr := strconv.Atoi(prefix)
+		if convErr != nil {
+			err = fmt.Errorf("migration file %s has invalid version: %w", name, convErr)
+			return
+		}
+		query, readErr := migrationFiles.ReadFile(path.Join("migrations", name))
+		if readErr != nil {
+			err = readErr
+			return
+		}
+		migrations = append(migrations, migration{
+			Version: version,
+			Name:    strings.TrimSuffix(name, ".sql"),
+			Query:   string(query),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return
+}
+
+// Migrate applies all not yet applied migrations from the migrations directory.
+func (p *PostgresClient) Migrate(ctx context.Context) (err error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return
+	}
+
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationsLockKey); err != nil {
+		return
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationsLockKey)
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    INTEGER PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`)
+	if err != nil {
+		return
+	}
+
+	applied := make(map[int]bool)
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return
+	}
+	for rows.Next() {
+		var version int
+		if err = rows.Scan(&version); err != nil {
+			rows.Close()
+			return
+		}
+		applied[version] = true
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+		if err = applyMigration(ctx, conn, m); err != nil {
+			err = fmt.Errorf("migration %s failed: %w", m.Name, err)
+			return
+		}
+		log.Printf("successfully applied postgres migration %s\n", m.Name)
+	}
+	return
+}
+
+// VerifySchema checks that all migrations are applied.
+func (p *PostgresClient) VerifySchema(ctx context.Context) (err error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return
+	}
+	for _, m := range migrations {
+		var applied bool
+		err = p.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", m.Version).Scan(&applied)
+		if err != nil {
+			err = fmt.Errorf("unable to check migration %s, run migrations: %w", m.Name, err)
+			return
+		}
+		if !applied {
+			err = fmt.Errorf("migration %s is not applied, run migrations", m.Name)
+			return
+		}
+	}
+	return
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, m migration) (err error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, m.Query); err != nil {
+		return
+	}
+	if _, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", m.Version, m.Name); err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
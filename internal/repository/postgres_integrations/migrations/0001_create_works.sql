@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE IF NOT EXISTS works (
+    id                 CHAR(24) PRIMARY KEY,
+    work_id            TEXT NOT NULL,
+    deadline           TIMESTAMPTZ,
+    duration_minutes   INTEGER NOT NULL DEFAULT 0,
+    priority           TEXT NOT NULL DEFAULT '',
+    start_date         TIMESTAMPTZ NOT NULL,
+    end_date           TIMESTAMPTZ NOT NULL,
+    period             TSTZRANGE GENERATED ALWAYS AS (tstzrange(start_date, end_date, '[)')) STORED,
+    status             TEXT NOT NULL DEFAULT '',
+    work_type          TEXT NOT NULL DEFAULT '',
+    zones              TEXT[] NOT NULL DEFAULT '{}',
+    compression_rate   REAL NOT NULL DEFAULT 0,
+    initial_duration   INTEGER NOT NULL DEFAULT 0,
+    initial_start_date TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS works_work_id_idx ON works (work_id);
+CREATE INDEX IF NOT EXISTS works_start_end_idx ON works (start_date, end_date);
+CREATE INDEX IF NOT EXISTS works_period_status_idx ON works USING GIST (period, status);
+CREATE INDEX IF NOT EXISTS works_zones_idx ON works USING GIN (zones);
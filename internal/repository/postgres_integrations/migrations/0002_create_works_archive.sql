@@ -0,0 +1,3 @@
+CREATE TABLE IF NOT EXISTS works_archive (LIKE works INCLUDING ALL);
+
+ALTER TABLE works_archive ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -0,0 +1,2 @@
+ALTER TABLE works ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE works_archive ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '';
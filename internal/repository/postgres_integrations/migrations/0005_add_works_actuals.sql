@@ -0,0 +1,11 @@
+ALTER TABLE works ADD COLUMN IF NOT EXISTS team TEXT NOT NULL DEFAULT '';
+ALTER TABLE works ADD COLUMN IF NOT EXISTS actual_start TIMESTAMPTZ;
+ALTER TABLE works ADD COLUMN IF NOT EXISTS actual_end TIMESTAMPTZ;
+ALTER TABLE works ADD COLUMN IF NOT EXISTS prolongation_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE works ADD COLUMN IF NOT EXISTS prolongation_minutes INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE works_archive ADD COLUMN IF NOT EXISTS team TEXT NOT NULL DEFAULT '';
+ALTER TABLE works_archive ADD COLUMN IF NOT EXISTS actual_start TIMESTAMPTZ;
+ALTER TABLE works_archive ADD COLUMN IF NOT EXISTS actual_end TIMESTAMPTZ;
+ALTER TABLE works_archive ADD COLUMN IF NOT EXISTS prolongation_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE works_archive ADD COLUMN IF NOT EXISTS prolongation_minutes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE works ADD COLUMN IF NOT EXISTS hold_until TIMESTAMPTZ;
+ALTER TABLE works_archive ADD COLUMN IF NOT EXISTS hold_until TIMESTAMPTZ;
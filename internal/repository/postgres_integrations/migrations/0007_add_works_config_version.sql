@@ -0,0 +1,2 @@
+ALTER TABLE works ADD COLUMN IF NOT EXISTS config_version BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE works_archive ADD COLUMN IF NOT EXISTS config_version BIGINT NOT NULL DEFAULT 0;
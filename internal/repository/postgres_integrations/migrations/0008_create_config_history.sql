@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS config_history (
+    revision   BIGSERIAL PRIMARY KEY,
+    document   TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
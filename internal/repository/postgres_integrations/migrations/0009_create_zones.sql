@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS zones (
+    zone_id        TEXT PRIMARY KEY,
+    windows        JSONB NOT NULL,
+    pause_minutes  INTEGER NOT NULL DEFAULT 0,
+    black_listed   BOOLEAN NOT NULL DEFAULT FALSE,
+    decommissioned BOOLEAN NOT NULL DEFAULT FALSE
+);
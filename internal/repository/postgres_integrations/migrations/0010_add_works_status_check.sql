@@ -0,0 +1,4 @@
+-- statuses added later need a new migration replacing the constraint, existing rows are not checked
+ALTER TABLE works DROP CONSTRAINT IF EXISTS works_status_check;
+ALTER TABLE works ADD CONSTRAINT works_status_check
+    CHECK (status IN ('held', 'planned', 'in_progress', 'overdue', 'completed', 'canceled', 'failed', 'rolled_back', 'aborted')) NOT VALID;
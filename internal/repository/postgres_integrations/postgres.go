@@ -0,0 +1,351 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/exp/slices"
+)
+
+const workColumns = `id, work_id, deadline, duration_minutes, priority, start_date, status, work_type, zones, compression_rate, initial_duration, initial_start_date, reason, team, actual_start, actual_end, prolongation_count, prolongation_minutes, hold_until, config_version`
+
+// columns of projectable work fields
+var fieldColumns = map[string]string{
+	"workId":          "work_id",
+	"zones":           "zones",
+	"startDate":       "start_date",
+	"durationMinutes": "duration_minutes",
+	"deadline":        "deadline",
+	"status":          "status",
+	"workType":        "work_type",
+	"priority":        "priority",
+	"reason":          "reason",
+	"team":            "team",
+}
+
+// sort expressions, null deadline is sorted as zero time same as in repository.SortValue
+var sortColumns = map[repository.SortField]string{
+	repository.SortByStartDate: "start_date",
+	repository.SortByEndDate:   "end_date",
+	repository.SortByDeadline:  "COALESCE(deadline, '0001-01-01 00:00:00+00')",
+}
+
+type PostgresClient struct {
+	db *sql.DB
+}
+
+// Options are postgres connection settings.
+type Options struct {
+	URI          string
+	MaxOpenConns int
+}
+
+func NewPostgresClient(ctx context.Context, o Options) (c *PostgresClient, err error) {
+	if o.URI == "" {
+		err = fmt.Errorf("empty postgres uri for connection string")
+		return
+	}
+
+	db, err := sql.Open("postgres", o.URI)
+	if err != nil {
+		return
+	}
+	if o.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(o.MaxOpenConns)
+	}
+	if err = db.PingContext(ctx); err != nil {
+		db.Close()
+		return
+	}
+
+	c = &PostgresClient{
+		db: db,
+	}
+	return
+}
+
+var _ repository.ReadWriteRepository = (*PostgresClient)(nil)
+var _ repository.Migrator = (*PostgresClient)(nil)
+
+func (p *PostgresClient) Add(ctx context.Context, work *models.WorkItem) (result *models.WorkItem, err error) {
+	if work.Id.IsZero() {
+		work.Id = primitive.NewObjectID()
+	}
+	work.EndDate = work.EndTime()
+	_, err = p.db.ExecContext(ctx, `INSERT INTO works (`+workColumns+`, end_date)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)`, workArgs(work)...)
+	if err != nil {
+		return
+	}
+	result = work
+	log.Printf("successfully inserted work with document id %v\n", work.Id)
+	return
+}
+
+func (p *PostgresClient) Update(ctx context.Context, work *models.WorkItem) (result *models.WorkItem, err error) {
+	if work.Id.IsZero() {
+		work.Id = primitive.NewObjectID()
+	}
+	work.EndDate = work.EndTime()
+	out, err := p.db.ExecContext(ctx, `INSERT INTO works (`+workColumns+`, end_date)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+ON CONFLICT (id) DO UPDATE SET
+	work_id = EXCLUDED.work_id,
+	deadline = EXCLUDED.deadline,
+	duration_minutes = EXCLUDED.duration_minutes,
+	priority = EXCLUDED.priority,
+	start_date = EXCLUDED.start_date,
+	status = EXCLUDED.status,
+	work_type = EXCLUDED.work_type,
+	zones = EXCLUDED.zones,
+	compression_rate = EXCLUDED.compression_rate,
+	initial_duration = EXCLUDED.initial_duration,
+	initial_start_date = EXCLUDED.initial_start_date,
+	reason = EXCLUDED.reason,
+	team = EXCLUDED.team,
+	actual_start = EXCLUDED.actual_start,
+	actual_end = EXCLUDED.actual_end,
+	prolongation_count = EXCLUDED.prolongation_count,
+	prolongation_minutes = EXCLUDED.prolongation_minutes,
+	hold_until = EXCLUDED.hold_until,
+	config_version = EXCLUDED.config_version,
+	end_date = EXCLUDED.end_date`, workArgs(work)...)
+	if err != nil {
+		return
+	}
+	result = work
+	affected, _ := out.RowsAffected()
+	log.Printf("successfully updated %v work rows with id %v\n", affected, work.Id)
+	return
+}
+
+func (p *PostgresClient) Delete(ctx context.Context, work *models.WorkItem) (err error) {
+	out, err := p.db.ExecContext(ctx, `DELETE FROM works WHERE id = $1`, work.Id.Hex())
+	if err != nil {
+		return
+	}
+	if affected, _ := out.RowsAffected(); affected == 0 {
+		return repository.NewErrorNotFound(fmt.Sprintf("work row with id %s not found", work.Id.Hex()))
+	}
+	log.Printf("successfully deleted work row with id %v\n", work.Id)
+	return
+}
+
+func (p *PostgresClient) GetById(ctx context.Context, id string) (results []*models.WorkItem, err error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT `+workColumns+` FROM works WHERE work_id = $1 ORDER BY start_date`, id)
+	if err != nil {
+		return
+	}
+	results, err = scanWorks(rows)
+	if err == nil && len(results) == 0 {
+		err = repository.NewErrorNotFound(fmt.Sprintf("work with id %s not found", id))
+	}
+	return
+}
+
+func (p *PostgresClient) List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string, mode repository.ListMode) (result []*models.WorkItem, err error) {
+	where, args := listCondition(from, to, zones, statuses, mode)
+	query := `SELECT ` + workColumns + ` FROM works WHERE ` + where + ` ORDER BY start_date`
+
+	log.Printf("searching for work rows with query %s %v\n", query, args)
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+	return scanWorks(rows)
+}
+
+func (p *PostgresClient) ListPage(ctx context.Context, query repository.PageQuery) (page repository.Page, err error) {
+	cursor, err := query.Normalize()
+	if err != nil {
+		return
+	}
+	direction, compare := "ASC", ">"
+	if query.SortOrder == repository.SortDesc {
+		direction, compare = "DESC", "<"
+	}
+	sortColumn := sortColumns[query.SortBy]
+
+	where, args := listCondition(query.From, query.To, query.Zones, query.Statuses, query.Mode)
+	if cursor != nil {
+		args = append(args, cursor.Value, cursor.Id.Hex())
+		where += fmt.Sprintf(" AND (%s, id) %s ($%d, $%d)", sortColumn, compare, len(args)-1, len(args))
+	}
+
+	columns := []string{"id", "start_date", "duration_minutes", "deadline"}
+	for _, f := range query.Fields {
+		if !slices.Contains(columns, fieldColumns[f]) {
+			columns = append(columns, fieldColumns[f])
+		}
+	}
+	if len(query.Fields) == 0 {
+		columns = strings.Split(workColumns, ", ")
+	}
+
+	// one more row to know if there is a next page
+	args = append(args, query.Limit+1)
+	sqlQuery := fmt.Sprintf(`SELECT %s FROM works WHERE %s ORDER BY %s %s, id %s LIMIT $%d`,
+		strings.Join(columns, ", "), where, sortColumn, direction, direction, len(args))
+
+	log.Printf("searching for work rows page with query %s %v\n", sqlQuery, args)
+	rows, err := p.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return
+	}
+	works, err := scanColumns(rows, columns)
+	if err != nil {
+		return
+	}
+	if len(works) > query.Limit {
+		works = works[:query.Limit]
+		page.NextCursor = repository.NextCursor(works[len(works)-1], query)
+	}
+	for _, work := range works {
+		page.Works = append(page.Works, repository.Project(work, query.Fields))
+	}
+	return
+}
+
+func listCondition(from time.Time, to time.Time, zones []string, statuses []string, mode repository.ListMode) (where string, args []any) {
+	switch mode {
+	case repository.ListModeStartsIn:
+		where = `tstzrange($1, $2, '[]') @> start_date`
+	case repository.ListModeEndsIn:
+		where = `tstzrange($1, $2, '[]') @> end_date`
+	default:
+		where = `period && tstzrange($1, $2, '[]')`
+	}
+	args = []any{from, to}
+	if len(zones) > 0 {
+		args = append(args, pq.Array(zones))
+		where += fmt.Sprintf(" AND zones && $%d", len(args))
+	}
+	if len(statuses) > 0 {
+		args = append(args, pq.Array(statuses))
+		where += fmt.Sprintf(" AND status = ANY($%d)", len(args))
+	}
+	return
+}
+
+func workArgs(work *models.WorkItem) []any {
+	return []any{
+		work.Id.Hex(),
+		work.WorkId,
+		nullTime(work.Deadline),
+		work.DurationMinutes,
+		work.Priority,
+		work.StartDate,
+		work.Status,
+		work.WorkType,
+		pq.Array(work.Zones),
+		work.CompressionRate,
+		work.InitialDuration,
+		nullTime(work.InitialStartDate),
+		work.Reason,
+		work.Team,
+		nullTime(work.ActualStart),
+		nullTime(work.ActualEnd),
+		work.ProlongationCount,
+		work.ProlongationMinutes,
+		nullTime(work.HoldUntil),
+		work.ConfigVersion,
+		work.EndDate,
+	}
+}
+
+func scanWorks(rows *sql.Rows) (result []*models.WorkItem, err error) {
+	return scanColumns(rows, strings.Split(workColumns, ", "))
+}
+
+func scanColumns(rows *sql.Rows, columns []string) (result []*models.WorkItem, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			wi               models.WorkItem
+			id               string
+			deadline         sql.NullTime
+			initialStartDate sql.NullTime
+			actualStart      sql.NullTime
+			actualEnd        sql.NullTime
+			holdUntil        sql.NullTime
+		)
+		dest := make([]any, 0, len(columns))
+		for _, column := range columns {
+			switch column {
+			case "id":
+				dest = append(dest, &id)
+			case "work_id":
+				dest = append(dest, &wi.WorkId)
+			case "deadline":
+				dest = append(dest, &deadline)
+			case "duration_minutes":
+				dest = append(dest, &wi.DurationMinutes)
+			case "priority":
+				dest = append(dest, &wi.Priority)
+			case "start_date":
+				dest = append(dest, &wi.StartDate)
+			case "status":
+				dest = append(dest, &wi.Status)
+			case "work_type":
+				dest = append(dest, &wi.WorkType)
+			case "zones":
+				dest = append(dest, pq.Array(&wi.Zones))
+			case "compression_rate":
+				dest = append(dest, &wi.CompressionRate)
+			case "initial_duration":
+				dest = append(dest, &wi.InitialDuration)
+			case "initial_start_date":
+				dest = append(dest, &initialStartDate)
+			case "reason":
+				dest = append(dest, &wi.Reason)
+			case "team":
+				dest = append(dest, &wi.Team)
+			case "actual_start":
+				dest = append(dest, &actualStart)
+			case "actual_end":
+				dest = append(dest, &actualEnd)
+			case "prolongation_count":
+				dest = append(dest, &wi.ProlongationCount)
+			case "prolongation_minutes":
+				dest = append(dest, &wi.ProlongationMinutes)
+			case "hold_until":
+				dest = append(dest, &holdUntil)
+			case "config_version":
+				dest = append(dest, &wi.ConfigVersion)
+			default:
+				err = fmt.Errorf("unexpected works column %s", column)
+				return
+			}
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return
+		}
+		if wi.Id, err = primitive.ObjectIDFromHex(id); err != nil {
+			return
+		}
+		wi.Deadline = deadline.Time
+		wi.InitialStartDate = initialStartDate.Time
+		wi.ActualStart = actualStart.Time
+		wi.ActualEnd = actualEnd.Time
+		wi.HoldUntil = holdUntil.Time
+		result = append(result, &wi)
+	}
+	err = rows.Err()
+	return
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t,
+		Valid: !t.IsZero(),
+	}
+}
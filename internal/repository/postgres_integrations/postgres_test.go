@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/repository/repositorytest"
+)
+
+// TestContract runs against a real database, e.g. started with `make test-integration`.
+func TestContract(t *testing.T) {
+	uri := os.Getenv("POSTGRES_TEST_URI")
+	if uri == "" {
+		t.Skip("POSTGRES_TEST_URI is not set")
+	}
+	repositorytest.RunContractTests(t, func(t *testing.T) repository.ReadWriteRepository {
+		repo, err := NewPostgresClient(context.Background(), Options{URI: uri})
+		if err != nil {
+			t.Fatalf("unable to connect to postgres: %v", err)
+		}
+		if err := repo.Migrate(context.Background()); err != nil {
+			t.Fatalf("unable to migrate postgres: %v", err)
+		}
+		if err := repo.VerifySchema(context.Background()); err != nil {
+			t.Fatalf("unexpected schema after migrations: %v", err)
+		}
+		if _, err := repo.db.Exec(`TRUNCATE works, config_history, zones RESTART IDENTITY`); err != nil {
+			t.Fatalf("unable to clean works: %v", err)
+		}
+		t.Cleanup(func() { repo.db.Close() })
+		return repo
+	})
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+)
+
+var _ repository.ZoneRepository = (*PostgresClient)(nil)
+
+func (p *PostgresClient) ListZones(ctx context.Context) (zones []configuration.Zone, err error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT zone_id, windows, pause_minutes, black_listed, decommissioned FROM zones ORDER BY zone_id`)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	zones = []configuration.Zone{}
+	for rows.Next() {
+		var zone configuration.Zone
+		var windows []byte
+		if err = rows.Scan(&zone.ZoneId, &windows, &zone.PauseMinutes, &zone.BlackListed, &zone.Decommissioned); err != nil {
+			return
+		}
+		if err = json.Unmarshal(windows, &zone.Windows); err != nil {
+			return
+		}
+		zones = append(zones, zone)
+	}
+	err = rows.Err()
+	return
+}
+
+func (p *PostgresClient) SaveZone(ctx context.Context, zone configuration.Zone) (err error) {
+	windows, err := json.Marshal(zone.Windows)
+	if err != nil {
+		return
+	}
+	_, err = p.db.ExecContext(ctx, `INSERT INTO zones (zone_id, windows, pause_minutes, black_listed, decommissioned)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (zone_id) DO UPDATE SET
+	windows = EXCLUDED.windows,
+	pause_minutes = EXCLUDED.pause_minutes,
+	black_listed = EXCLUDED.black_listed,
+	decommissioned = EXCLUDED.decommissioned`, zone.ZoneId, windows, zone.PauseMinutes, zone.BlackListed, zone.Decommissioned)
+	return
+}
@@ -2,7 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"time"
+	"workScheduler/internal/configuration"
 	"workScheduler/internal/scheduler/models"
 )
 
@@ -20,17 +24,149 @@ func NewErrorNotFound(text string) *ErrorNotFound {
 	}
 }
 
+func IsNotFound(err error) bool {
+	var notFound *ErrorNotFound
+	return errors.As(err, &notFound)
+}
+
+// ErrorConflict is returned when a write is based on state changed by another writer.
+type ErrorConflict struct {
+	text string
+}
+
+func (e *ErrorConflict) Error() string {
+	return e.text
+}
+
+func NewErrorConflict(text string) *ErrorConflict {
+	return &ErrorConflict{
+		text: text,
+	}
+}
+
+func IsConflict(err error) bool {
+	var conflict *ErrorConflict
+	return errors.As(err, &conflict)
+}
+
+// OrderByStartDate orders works as GetById and List of all repositories return them.
+func OrderByStartDate(works []*models.WorkItem) {
+	sort.SliceStable(works, func(i, j int) bool {
+		return works[i].StartDate.Before(works[j].StartDate)
+	})
+}
+
+// ListMode defines how work interval [startDate, endDate) is matched against the [from, to] range in List.
+type ListMode string
+
+const (
+	// ListModeOverlaps matches works which are in progress at any moment of the range.
+	ListModeOverlaps ListMode = "overlaps"
+	// ListModeStartsIn matches works started inside the range.
+	ListModeStartsIn ListMode = "starts_in"
+	// ListModeEndsIn matches works ended inside the range.
+	ListModeEndsIn ListMode = "ends_in"
+)
+
+func ParseListMode(mode string) (ListMode, error) {
+	switch ListMode(mode) {
+	case "":
+		return ListModeOverlaps, nil
+	case ListModeOverlaps, ListModeStartsIn, ListModeEndsIn:
+		return ListMode(mode), nil
+	default:
+		return "", fmt.Errorf("unknown list mode %q, expected one of: %s, %s, %s", mode, ListModeOverlaps, ListModeStartsIn, ListModeEndsIn)
+	}
+}
+
+// Match reports whether work interval [start, end) matches the [from, to] range.
+func (m ListMode) Match(start time.Time, end time.Time, from time.Time, to time.Time) bool {
+	switch m {
+	case ListModeStartsIn:
+		return !start.Before(from) && !start.After(to)
+	case ListModeEndsIn:
+		return !end.Before(from) && !end.After(to)
+	default:
+		return !start.After(to) && end.After(from)
+	}
+}
+
 type ReadWriteRepository interface {
 	ReadRepository
 	WriteRepository
 }
 
 type ReadRepository interface {
+	// GetById returns all documents of the work ordered by start date, ErrorNotFound if there are none.
 	GetById(ctx context.Context, id string) ([]*models.WorkItem, error)
-	List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string) ([]*models.WorkItem, error)
+	List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string, mode ListMode) ([]*models.WorkItem, error)
+	ListPage(ctx context.Context, query PageQuery) (Page, error)
+}
+
+// Migrator is implemented by repositories with a versioned schema.
+type Migrator interface {
+	// Migrate applies not yet applied migrations.
+	Migrate(ctx context.Context) error
+	// VerifySchema returns an error if the schema is not ready for use.
+	VerifySchema(ctx context.Context) error
 }
 
 type WriteRepository interface {
 	Add(ctx context.Context, work *models.WorkItem) (*models.WorkItem, error)
+	// Update replaces the document with the same document id or inserts it.
 	Update(ctx context.Context, work *models.WorkItem) (*models.WorkItem, error)
+	// Delete removes the document with the same document id, ErrorNotFound if there is none.
+	Delete(ctx context.Context, work *models.WorkItem) error
+}
+
+// Lease is held by one replica until ExpiresAt. Token grows each time the lease gets a new
+// holder, so writes of a former holder can be fenced by it.
+type Lease struct {
+	Name      string    `bson:"_id"`
+	Holder    string    `bson:"holder"`
+	Token     int64     `bson:"token"`
+	ExpiresAt time.Time `bson:"expiresAt"`
+}
+
+type LeaseRepository interface {
+	// AcquireLease takes the lease if it is free or expired and prolongs it if it is held by holder,
+	// acquired is false if the lease is held by another holder.
+	AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (lease Lease, acquired bool, err error)
+	// ReleaseLease expires the lease if it is held by holder.
+	ReleaseLease(ctx context.Context, name string, holder string) error
+	// VerifyLease returns an error if the lease is expired or has another token.
+	VerifyLease(ctx context.Context, name string, token int64) error
+}
+
+// ConfigRevision is a config document applied at CreatedAt, revisions are numbered from 1.
+type ConfigRevision struct {
+	Revision  int64     `bson:"_id" json:"revision"`
+	Document  string    `bson:"document" json:"document"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+type ConfigHistoryRepository interface {
+	// SaveConfig stores document as revision previous+1, ErrorConflict is returned if the revision
+	// is already saved, e.g. by another replica.
+	SaveConfig(ctx context.Context, document string, previous int64) (ConfigRevision, error)
+	// ListConfigs returns revisions from the latest one.
+	ListConfigs(ctx context.Context) ([]ConfigRevision, error)
+	// GetConfig returns the revision, ErrorNotFound if there is none.
+	GetConfig(ctx context.Context, revision int64) (ConfigRevision, error)
+}
+
+// ZoneRepository keeps zones managed through API, they are merged over config file.
+type ZoneRepository interface {
+	// ListZones returns zones ordered by id.
+	ListZones(ctx context.Context) ([]configuration.Zone, error)
+	// SaveZone replaces the zone with the same id or inserts it.
+	SaveZone(ctx context.Context, zone configuration.Zone) error
+}
+
+// Archive keeps works removed from repository by retention.
+type Archive interface {
+	// Archive stores works, works which are already archived are skipped.
+	Archive(ctx context.Context, works []*models.WorkItem) error
+	// ListArchived returns archived works overlapping the [from, to] range ordered by start date.
+	ListArchived(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string) ([]*models.WorkItem, error)
 }
@@ -0,0 +1,510 @@
+// Package repositorytest contains contract tests which every repository.ReadWriteRepository
+// implementation must pass.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NewRepository must return an empty repository on each call.
+type NewRepository func(t *testing.T) repository.ReadWriteRepository
+
+var testTime = time.Date(2023, 04, 19, 10, 0, 0, 0, time.UTC)
+
+func RunContractTests(t *testing.T, newRepository NewRepository) {
+	t.Run("succees add and get work by id", func(t *testing.T) {
+		testAddGet(t, newRepository(t))
+	})
+	t.Run("succees get not found error", func(t *testing.T) {
+		testNotFound(t, newRepository(t))
+	})
+	t.Run("succees update work", func(t *testing.T) {
+		testUpdate(t, newRepository(t))
+	})
+	t.Run("succees delete work", func(t *testing.T) {
+		testDelete(t, newRepository(t))
+	})
+	t.Run("succees reject unknown status", func(t *testing.T) {
+		testUnknownStatus(t, newRepository(t))
+	})
+	t.Run("succees list with filters", func(t *testing.T) {
+		testListFilters(t, newRepository(t))
+	})
+	t.Run("succees list with range boundaries", func(t *testing.T) {
+		testListModes(t, newRepository(t))
+	})
+	t.Run("succees list in start date order", func(t *testing.T) {
+		testListOrder(t, newRepository(t))
+	})
+	t.Run("succees list pages", func(t *testing.T) {
+		testListPage(t, newRepository(t))
+	})
+	t.Run("succees list pages by deadline", func(t *testing.T) {
+		testListPageByDeadline(t, newRepository(t))
+	})
+	t.Run("succees concurrent writers", func(t *testing.T) {
+		testConcurrentWriters(t, newRepository(t))
+	})
+	t.Run("succees config history", func(t *testing.T) {
+		history, ok := newRepository(t).(repository.ConfigHistoryRepository)
+		if !ok {
+			t.Skip("config history is not supported")
+		}
+		testConfigHistory(t, history)
+	})
+	t.Run("succees save and list zones", func(t *testing.T) {
+		zones, ok := newRepository(t).(repository.ZoneRepository)
+		if !ok {
+			t.Skip("zones are not supported")
+		}
+		testZones(t, zones)
+	})
+}
+
+func newWork(workId string, zone string, start time.Time, status string) *models.WorkItem {
+	return &models.WorkItem{
+		WorkId:          workId,
+		Zones:           []string{zone},
+		StartDate:       start,
+		DurationMinutes: 60,
+		Deadline:        start.Add(4 * time.Hour),
+		Priority:        "regular",
+		WorkType:        "manual",
+		Status:          models.Status(status),
+		CompressionRate: 1,
+		InitialDuration: 60,
+	}
+}
+
+func add(t *testing.T, repo repository.ReadWriteRepository, works ...*models.WorkItem) {
+	for _, work := range works {
+		if _, err := repo.Add(context.Background(), work); err != nil {
+			t.Fatalf("unexpected error on add: %v", err)
+		}
+		if work.Id.IsZero() {
+			t.Fatalf("document id is not assigned on add: %v", work)
+		}
+	}
+}
+
+func equalWorks(a *models.WorkItem, b *models.WorkItem) bool {
+	return a.Id == b.Id &&
+		a.WorkId == b.WorkId &&
+		fmt.Sprint(a.Zones) == fmt.Sprint(b.Zones) &&
+		a.StartDate.Equal(b.StartDate) &&
+		a.DurationMinutes == b.DurationMinutes &&
+		a.Deadline.Equal(b.Deadline) &&
+		a.Priority == b.Priority &&
+		a.WorkType == b.WorkType &&
+		a.Status == b.Status &&
+		a.Reason == b.Reason &&
+		a.Team == b.Team &&
+		a.ActualStart.Equal(b.ActualStart) &&
+		a.ActualEnd.Equal(b.ActualEnd) &&
+		a.ProlongationCount == b.ProlongationCount &&
+		a.ProlongationMinutes == b.ProlongationMinutes &&
+		a.HoldUntil.Equal(b.HoldUntil) &&
+		a.ConfigVersion == b.ConfigVersion &&
+		a.CompressionRate == b.CompressionRate &&
+		a.InitialDuration == b.InitialDuration
+}
+
+func workIds(works []*models.WorkItem) (ids []string) {
+	for _, work := range works {
+		ids = append(ids, work.WorkId)
+	}
+	return
+}
+
+func testAddGet(t *testing.T, repo repository.ReadWriteRepository) {
+	second := newWork("1", "Zone_2", testTime.Add(2*time.Hour), "planned")
+	first := newWork("1", "Zone_1", testTime, "planned")
+	add(t, repo, second, first, newWork("2", "Zone_1", testTime, "planned"))
+
+	works, err := repo.GetById(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("unexpected error on get: %v", err)
+	}
+	if len(works) != 2 {
+		t.Fatalf("unexpected works count: %v, want 2", len(works))
+	}
+	if !equalWorks(works[0], first) || !equalWorks(works[1], second) {
+		t.Errorf("unexpected works: %v, want %v", works, []*models.WorkItem{first, second})
+	}
+}
+
+func testNotFound(t *testing.T, repo repository.ReadWriteRepository) {
+	add(t, repo, newWork("1", "Zone_1", testTime, "planned"))
+
+	works, err := repo.GetById(context.Background(), "2")
+	if !repository.IsNotFound(err) {
+		t.Errorf("unexpected error: %v, want ErrorNotFound", err)
+	}
+	if len(works) != 0 {
+		t.Errorf("unexpected works: %v", works)
+	}
+}
+
+func testUpdate(t *testing.T, repo repository.ReadWriteRepository) {
+	ctx := context.Background()
+	work := newWork("1", "Zone_1", testTime, "planned")
+	add(t, repo, work)
+
+	work.Status = "in_progress"
+	work.Reason = "started by executor"
+	work.Team = "sre"
+	work.HoldUntil = testTime.Add(30 * time.Minute)
+	work.ConfigVersion = 3
+	work.ActualStart = testTime.Add(time.Hour)
+	work.Prolongate(90)
+	work.StartDate = testTime.Add(time.Hour)
+	if _, err := repo.Update(ctx, work); err != nil {
+		t.Fatalf("unexpected error on update: %v", err)
+	}
+	works, err := repo.GetById(ctx, "1")
+	if err != nil {
+		t.Fatalf("unexpected error on get: %v", err)
+	}
+	if len(works) != 1 || !equalWorks(works[0], work) {
+		t.Errorf("unexpected updated works: %v, want %v", works, work)
+	}
+	// end date must follow the new start and duration
+	listed, err := repo.List(ctx, testTime.Add(150*time.Minute), testTime.Add(3*time.Hour), nil, nil, repository.ListModeEndsIn)
+	if err != nil {
+		t.Fatalf("unexpected error on list: %v", err)
+	}
+	if len(listed) != 1 {
+		t.Errorf("unexpected works ended at new end date: %v", listed)
+	}
+
+	inserted := newWork("2", "Zone_1", testTime, "planned")
+	inserted.Id = primitive.NewObjectID()
+	if _, err := repo.Update(ctx, inserted); err != nil {
+		t.Fatalf("unexpected error on update of missing work: %v", err)
+	}
+	works, err = repo.GetById(ctx, "2")
+	if err != nil || len(works) != 1 || !equalWorks(works[0], inserted) {
+		t.Errorf("unexpected upserted works: %v %v", works, err)
+	}
+}
+
+func testDelete(t *testing.T, repo repository.ReadWriteRepository) {
+	ctx := context.Background()
+	deleted := newWork("1", "Zone_1", testTime, "completed")
+	kept := newWork("1", "Zone_2", testTime, "completed")
+	add(t, repo, deleted, kept)
+
+	if err := repo.Delete(ctx, deleted); err != nil {
+		t.Fatalf("unexpected error on delete: %v", err)
+	}
+	works, err := repo.GetById(ctx, "1")
+	if err != nil || len(works) != 1 || !equalWorks(works[0], kept) {
+		t.Errorf("unexpected works after delete: %v %v", works, err)
+	}
+	listed, err := repo.List(ctx, testTime, testTime.Add(time.Hour), []string{"Zone_1"}, nil, repository.ListModeOverlaps)
+	if err != nil || len(listed) != 0 {
+		t.Errorf("unexpected listed works after delete: %v %v", listed, err)
+	}
+	if err := repo.Delete(ctx, deleted); !repository.IsNotFound(err) {
+		t.Errorf("unexpected error on delete of missing work: %v, want ErrorNotFound", err)
+	}
+}
+
+func testUnknownStatus(t *testing.T, repo repository.ReadWriteRepository) {
+	if _, err := repo.Add(context.Background(), newWork("1", "Zone_1", testTime, "unknown")); err == nil {
+		t.Errorf("work with unknown status is added")
+	}
+
+	work := newWork("2", "Zone_1", testTime, "planned")
+	add(t, repo, work)
+	work.Status = "unknown"
+	if _, err := repo.Update(context.Background(), work); err == nil {
+		t.Errorf("work is updated to unknown status")
+	}
+	works, err := repo.GetById(context.Background(), "2")
+	if err != nil {
+		t.Fatalf("unexpected error on get: %v", err)
+	}
+	if len(works) != 1 || works[0].Status != "planned" {
+		t.Errorf("unexpected works after rejected update: %v", works)
+	}
+}
+
+func testListFilters(t *testing.T, repo repository.ReadWriteRepository) {
+	add(t, repo,
+		newWork("1", "Zone_1", testTime, "planned"),
+		newWork("2", "Zone_2", testTime.Add(time.Hour), "in_progress"),
+		newWork("3", "Zone_3", testTime.Add(2*time.Hour), "canceled"),
+		newWork("4", "Zone_1", testTime.Add(3*time.Hour), "canceled"),
+	)
+
+	tests := []struct {
+		name     string
+		zones    []string
+		statuses []string
+		expected string
+	}{
+		{"all", nil, nil, "[1 2 3 4]"},
+		{"zones", []string{"Zone_1", "Zone_3"}, nil, "[1 3 4]"},
+		{"statuses", nil, []string{"planned", "in_progress"}, "[1 2]"},
+		{"zones and statuses", []string{"Zone_1"}, []string{"canceled"}, "[4]"},
+		{"no matches", []string{"Zone_4"}, nil, "[]"},
+	}
+	for _, tt := range tests {
+		works, err := repo.List(context.Background(), testTime, testTime.Add(24*time.Hour), tt.zones, tt.statuses, repository.ListModeOverlaps)
+		if err != nil {
+			t.Fatalf("unexpected error on list %s: %v", tt.name, err)
+		}
+		if got := fmt.Sprint(workIds(works)); got != tt.expected {
+			t.Errorf("unexpected works on list %s: %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func testListModes(t *testing.T, repo repository.ReadWriteRepository) {
+	// work interval is [10:00, 11:00)
+	add(t, repo, newWork("1", "Zone_1", testTime, "planned"))
+
+	tests := []struct {
+		mode     repository.ListMode
+		from     time.Duration
+		to       time.Duration
+		expected bool
+	}{
+		{repository.ListModeOverlaps, -time.Hour, 0, true},
+		{repository.ListModeOverlaps, -time.Hour, -time.Minute, false},
+		{repository.ListModeOverlaps, 30 * time.Minute, 40 * time.Minute, true},
+		{repository.ListModeOverlaps, 59 * time.Minute, 2 * time.Hour, true},
+		{repository.ListModeOverlaps, time.Hour, 2 * time.Hour, false},
+		{repository.ListModeStartsIn, 0, 0, true},
+		{repository.ListModeStartsIn, -time.Hour, 0, true},
+		{repository.ListModeStartsIn, time.Minute, 2 * time.Hour, false},
+		{repository.ListModeEndsIn, time.Hour, 2 * time.Hour, true},
+		{repository.ListModeEndsIn, 0, 59 * time.Minute, false},
+	}
+	for _, tt := range tests {
+		from, to := testTime.Add(tt.from), testTime.Add(tt.to)
+		works, err := repo.List(context.Background(), from, to, nil, nil, tt.mode)
+		if err != nil {
+			t.Fatalf("unexpected error on list %s: %v", tt.mode, err)
+		}
+		if (len(works) == 1) != tt.expected {
+			t.Errorf("unexpected works on list %s from %s to %s: %v, want match %v", tt.mode, from, to, works, tt.expected)
+		}
+	}
+}
+
+func testListOrder(t *testing.T, repo repository.ReadWriteRepository) {
+	// works end in reverse order of their starts
+	for i, duration := range []int32{240, 120, 30} {
+		work := newWork(fmt.Sprint(i+1), "Zone_1", testTime.Add(time.Duration(i)*time.Hour), "planned")
+		work.DurationMinutes, work.InitialDuration = duration, duration
+		add(t, repo, work)
+	}
+
+	for _, mode := range []repository.ListMode{repository.ListModeOverlaps, repository.ListModeStartsIn, repository.ListModeEndsIn} {
+		works, err := repo.List(context.Background(), testTime, testTime.Add(24*time.Hour), nil, nil, mode)
+		if err != nil {
+			t.Fatalf("unexpected error on list %s: %v", mode, err)
+		}
+		if got := fmt.Sprint(workIds(works)); got != "[1 2 3]" {
+			t.Errorf("unexpected order on list %s: %v, want [1 2 3]", mode, got)
+		}
+	}
+}
+
+func testListPage(t *testing.T, repo repository.ReadWriteRepository) {
+	// works 1 and 2 share start date to check order by document id
+	add(t, repo,
+		newWork("3", "Zone_1", testTime.Add(2*time.Hour), "planned"),
+		newWork("1", "Zone_1", testTime, "planned"),
+		newWork("2", "Zone_2", testTime, "planned"),
+		newWork("5", "Zone_1", testTime.Add(4*time.Hour), "planned"),
+		newWork("4", "Zone_1", testTime.Add(3*time.Hour), "planned"),
+	)
+
+	tests := []struct {
+		order    repository.SortOrder
+		expected string
+	}{
+		{repository.SortAsc, "[1 2 3 4 5]"},
+		{repository.SortDesc, "[5 4 3 2 1]"},
+	}
+	for _, tt := range tests {
+		query := repository.PageQuery{
+			From:      testTime,
+			To:        testTime.Add(24 * time.Hour),
+			SortOrder: tt.order,
+			Limit:     2,
+			Fields:    []string{"workId"},
+		}
+		var works []*models.WorkItem
+		for pages := 0; pages < 5; pages++ {
+			page, err := repo.ListPage(context.Background(), query)
+			if err != nil {
+				t.Fatalf("unexpected error on list page: %v", err)
+			}
+			works = append(works, page.Works...)
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		if got := fmt.Sprint(workIds(works)); got != tt.expected {
+			t.Errorf("unexpected paged works in %s order: %v, want %v", tt.order, got, tt.expected)
+		}
+		for _, work := range works {
+			if work.Id.IsZero() || work.Priority != "" {
+				t.Errorf("unexpected projected work: %v", work)
+			}
+		}
+	}
+}
+
+func testListPageByDeadline(t *testing.T, repo repository.ReadWriteRepository) {
+	// works without deadline are sorted as zero deadline and paged by document id
+	var works []*models.WorkItem
+	for _, workId := range []string{"1", "2", "3", "4"} {
+		work := newWork(workId, "Zone_1", testTime, "planned")
+		work.Deadline = time.Time{}
+		works = append(works, work)
+	}
+	add(t, repo, works...)
+	add(t, repo, newWork("5", "Zone_1", testTime, "planned"))
+
+	tests := []struct {
+		order    repository.SortOrder
+		expected string
+	}{
+		{repository.SortAsc, "[1 2 3 4 5]"},
+		{repository.SortDesc, "[5 4 3 2 1]"},
+	}
+	for _, tt := range tests {
+		query := repository.PageQuery{
+			From:      testTime,
+			To:        testTime.Add(24 * time.Hour),
+			SortBy:    repository.SortByDeadline,
+			SortOrder: tt.order,
+			Limit:     2,
+		}
+		var paged []*models.WorkItem
+		for pages := 0; pages < 5; pages++ {
+			page, err := repo.ListPage(context.Background(), query)
+			if err != nil {
+				t.Fatalf("unexpected error on list page: %v", err)
+			}
+			paged = append(paged, page.Works...)
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		if got := fmt.Sprint(workIds(paged)); got != tt.expected {
+			t.Errorf("unexpected paged works by deadline in %s order: %v, want %v", tt.order, got, tt.expected)
+		}
+	}
+}
+
+func testConcurrentWriters(t *testing.T, repo repository.ReadWriteRepository) {
+	const writers = 20
+	ctx := context.Background()
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			work := newWork(fmt.Sprint(i), "Zone_1", testTime.Add(time.Duration(i)*time.Minute), "planned")
+			if _, err := repo.Add(ctx, work); err != nil {
+				errs <- err
+				return
+			}
+			work.Status = "in_progress"
+			if _, err := repo.Update(ctx, work); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("unexpected error on concurrent write: %v", err)
+	}
+
+	works, err := repo.List(ctx, testTime, testTime.Add(24*time.Hour), nil, []string{"in_progress"}, repository.ListModeOverlaps)
+	if err != nil {
+		t.Fatalf("unexpected error on list: %v", err)
+	}
+	if len(works) != writers {
+		t.Errorf("unexpected works count: %v, want %v", len(works), writers)
+	}
+}
+
+func testConfigHistory(t *testing.T, history repository.ConfigHistoryRepository) {
+	ctx := context.Background()
+
+	first, err := history.SaveConfig(ctx, "max_deadline_days: 28\n", 0)
+	if err != nil {
+		t.Fatalf("unexpected error on save: %v", err)
+	}
+	second, err := history.SaveConfig(ctx, "max_deadline_days: 14\n", first.Revision)
+	if err != nil {
+		t.Fatalf("unexpected error on save: %v", err)
+	}
+	if first.Revision != 1 || second.Revision != 2 {
+		t.Errorf("unexpected revisions: %v, %v, want 1, 2", first.Revision, second.Revision)
+	}
+	// concurrent writer based on the same revision
+	if _, err = history.SaveConfig(ctx, "max_deadline_days: 7\n", first.Revision); !repository.IsConflict(err) {
+		t.Errorf("unexpected error for already saved revision: %v", err)
+	}
+
+	revisions, err := history.ListConfigs(ctx)
+	if err != nil || len(revisions) != 2 || revisions[0].Revision != second.Revision || revisions[1].Document != first.Document {
+		t.Errorf("unexpected config history: %v %v", revisions, err)
+	}
+	got, err := history.GetConfig(ctx, first.Revision)
+	if err != nil || got.Document != first.Document {
+		t.Errorf("unexpected config revision: %v %v", got, err)
+	}
+	if _, err = history.GetConfig(ctx, 3); !repository.IsNotFound(err) {
+		t.Errorf("unexpected error for missing revision: %v", err)
+	}
+}
+
+func testZones(t *testing.T, repo repository.ZoneRepository) {
+	ctx := context.Background()
+
+	zones := []configuration.Zone{
+		{ZoneId: "Zone_2", BlackListed: true},
+		{ZoneId: "Zone_1", Windows: []configuration.Window{{StartHour: 23, EndHour: 6}}, PauseMinutes: 10},
+	}
+	for _, zone := range zones {
+		if err := repo.SaveZone(ctx, zone); err != nil {
+			t.Fatalf("unexpected error on save: %v", err)
+		}
+	}
+	decommissioned := configuration.Zone{ZoneId: "Zone_2", Decommissioned: true}
+	if err := repo.SaveZone(ctx, decommissioned); err != nil {
+		t.Fatalf("unexpected error on replace: %v", err)
+	}
+
+	listed, err := repo.ListZones(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error on list: %v", err)
+	}
+	if len(listed) != 2 || listed[0].ZoneId != "Zone_1" || len(listed[0].Windows) != 1 || listed[0].Windows[0] != zones[1].Windows[0] ||
+		listed[0].PauseMinutes != 10 || listed[1].BlackListed || !listed[1].Decommissioned {
+		t.Errorf("unexpected zones: %+v", listed)
+	}
+}
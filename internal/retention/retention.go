@@ -0,0 +1,93 @@
+package retention
+
+import (
+	"context"
+	"log"
+	"time"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+)
+
+const (
+	interval  = 10 * time.Minute
+	batchSize = 500
+)
+
+// Retention moves works in final statuses to archive when they are older than retention_days
+// of their status in config.
+type Retention struct {
+	Repository repository.ReadWriteRepository
+	Archive    repository.Archive
+	Config     *configuration.Configurator
+}
+
+func NewRetention(repo repository.ReadWriteRepository, archive repository.Archive, config *configuration.Configurator) *Retention {
+	return &Retention{
+		Repository: repo,
+		Archive:    archive,
+		Config:     config,
+	}
+}
+
+func (r *Retention) Run(ctx context.Context) {
+	go r.process(ctx)
+}
+
+func (r *Retention) process(ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.ArchiveOld(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ArchiveOld archives works ended before now minus retention days of their status.
+func (r *Retention) ArchiveOld(ctx context.Context, now time.Time) (archived int) {
+	for status, days := range r.Config.Current().RetentionDays {
+		before := now.Add(-time.Duration(days) * 24 * time.Hour)
+		count, err := r.archiveStatus(ctx, status, before)
+		archived += count
+		if err != nil {
+			log.Printf("WARNING: Find error while archiving %s works ended before %s: %s\n", status, before, err)
+		}
+	}
+	if archived > 0 {
+		log.Printf("successfully archived %v works\n", archived)
+	}
+	return
+}
+
+func (r *Retention) archiveStatus(ctx context.Context, status string, before time.Time) (archived int, err error) {
+	for {
+		// archived works are deleted, so each time the first page is taken
+		page, err := r.Repository.ListPage(ctx, repository.PageQuery{
+			From:     time.Unix(0, 0),
+			To:       before,
+			Statuses: []string{status},
+			Mode:     repository.ListModeEndsIn,
+			SortBy:   repository.SortByEndDate,
+			Limit:    batchSize,
+		})
+		if err != nil || len(page.Works) == 0 {
+			return archived, err
+		}
+		if err = r.Archive.Archive(ctx, page.Works); err != nil {
+			return archived, err
+		}
+		for _, work := range page.Works {
+			if err = r.Repository.Delete(ctx, work); err != nil && !repository.IsNotFound(err) {
+				return archived, err
+			}
+		}
+		archived += len(page.Works)
+		if page.NextCursor == "" {
+			return archived, nil
+		}
+	}
+}
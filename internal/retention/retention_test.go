@@ -0,0 +1,70 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"workScheduler/internal/configuration"
+	filearchive "workScheduler/internal/repository/file_archive"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
+	"workScheduler/internal/scheduler/models"
+)
+
+func TestArchiveOld(t *testing.T) {
+
+	t.Run("succees archive old works by status", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Date(2023, 04, 19, 10, 0, 0, 0, time.UTC)
+		daysAgo := func(days int) time.Time { return now.Add(-time.Duration(days) * 24 * time.Hour) }
+
+		repo := inmemoryrepository.NewInmemoryRepository()
+		works := []*models.WorkItem{
+			{WorkId: "old completed", StartDate: daysAgo(100), Status: "completed"},
+			{WorkId: "new completed", StartDate: daysAgo(10), Status: "completed"},
+			{WorkId: "old canceled", StartDate: daysAgo(40), Status: "canceled"},
+			{WorkId: "new canceled", StartDate: daysAgo(20), Status: "canceled"},
+			{WorkId: "old planned", StartDate: daysAgo(100), Status: "planned"},
+		}
+		for _, work := range works {
+			work.Zones = []string{"Zone_1"}
+			work.DurationMinutes = 60
+			repo.Add(ctx, work)
+		}
+
+		archive, err := filearchive.NewFileArchive(t.TempDir())
+		if err != nil {
+			t.Fatalf("unable to create archive: %v", err)
+		}
+		config := configuration.NewStaticConfigurator(&configuration.Config{
+			RetentionDays: map[string]int32{"completed": 30, "canceled": 30},
+		})
+		r := NewRetention(repo, archive, config)
+
+		if archived := r.ArchiveOld(ctx, now); archived != 2 {
+			t.Errorf("unexpected archived count: %v, want 2", archived)
+		}
+		if archived := r.ArchiveOld(ctx, now); archived != 0 {
+			t.Errorf("unexpected archived count on second run: %v, want 0", archived)
+		}
+
+		kept, _ := repo.List(ctx, daysAgo(365), now, nil, nil, "")
+		if fmt.Sprint(workIds(kept)) != "[old planned new canceled new completed]" {
+			t.Errorf("unexpected kept works: %v", workIds(kept))
+		}
+		archived, err := archive.ListArchived(ctx, daysAgo(365), now, []string{"Zone_1"}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error on list archived: %v", err)
+		}
+		if fmt.Sprint(workIds(archived)) != "[old completed old canceled]" {
+			t.Errorf("unexpected archived works: %v", workIds(archived))
+		}
+	})
+}
+
+func workIds(works []*models.WorkItem) (ids []string) {
+	for _, work := range works {
+		ids = append(ids, work.WorkId)
+	}
+	return
+}
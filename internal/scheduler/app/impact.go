@@ -0,0 +1,173 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync/atomic"
+	"time"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/scheduler/models"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	RuleBlackList         = "black_list"
+	RuleWhiteList         = "white_list"
+	RuleMaxDuration       = "max_work_duration_minutes"
+	RuleMinAvialableZones = "min_avialable_zones"
+)
+
+// Violation is a planned work which is not allowed by config.
+type Violation struct {
+	WorkId  string `json:"workId"`
+	Zone    string `json:"zone,omitempty"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Proposal is a new schedule of violating work under checked config, it is not saved. Moved or
+// canceled other works are included in schedule.
+type Proposal struct {
+	WorkId          string             `json:"workId"`
+	Schedule        []*models.WorkItem `json:"schedule,omitempty"`
+	UserMustApprove bool               `json:"userMustApprove"`
+	Error           string             `json:"error,omitempty"`
+}
+
+// Impact lists planned and held works which become illegal under config.
+type Impact struct {
+	ConfigVersion int64       `json:"configVersion"`
+	CheckedAt     time.Time   `json:"checkedAt"`
+	Violations    []Violation `json:"violations"`
+	Proposals     []Proposal  `json:"proposals,omitempty"`
+}
+
+// withConfig returns scheduler deciding with the given config instead of the latest one.
+func (sch *Scheduler) withConfig(conf *configuration.Config) *Scheduler {
+	latest := &atomic.Pointer[configuration.Config]{}
+	latest.Store(conf)
+	return &Scheduler{
+		Config:     conf,
+		Repository: sch.Repository,
+		ctx:        sch.ctx,
+		latest:     latest,
+	}
+}
+
+// Impact checks works planned after now against config, with propose violating works are
+// rescheduled under config without saving.
+func (sch *Scheduler) Impact(conf *configuration.Config, now time.Time, propose bool) (impact *Impact, err error) {
+	// works planned under the live config may be further than max_deadline_days of the checked one
+	days := Max(conf.LongestDeadlineDays(), sch.latest.Load().LongestDeadlineDays())
+	sch = sch.withConfig(conf)
+	impact = &Impact{
+		ConfigVersion: conf.Version,
+		CheckedAt:     now,
+		Violations:    []Violation{},
+	}
+
+	zonesSchedule, err := sch.getAllZonesSchedule(now, now.Add(24*time.Hour*time.Duration(days)))
+	if err != nil {
+		return
+	}
+	works := map[string]*models.WorkItem{}
+	for _, zoneSchedule := range zonesSchedule.scheduleByZones {
+		for _, iw := range zoneSchedule {
+			if iw.Work.Status != StatusPlanned && iw.Work.Status != StatusHeld {
+				continue
+			}
+			if iw.Work.StartDate.Before(now) {
+				continue
+			}
+			works[iw.Work.Id.Hex()] = iw.Work
+		}
+	}
+
+	violating := map[string][]*models.WorkItem{}
+	for _, wi := range works {
+		violations := sch.violations(zonesSchedule, wi)
+		if len(violations) > 0 {
+			impact.Violations = append(impact.Violations, violations...)
+			violating[wi.WorkId] = append(violating[wi.WorkId], wi)
+		}
+	}
+	sort.Slice(impact.Violations, func(i, j int) bool {
+		a, b := impact.Violations[i], impact.Violations[j]
+		if a.WorkId != b.WorkId {
+			return a.WorkId < b.WorkId
+		}
+		if a.Zone != b.Zone {
+			return a.Zone < b.Zone
+		}
+		return a.Rule < b.Rule
+	})
+
+	if propose {
+		for workId, parts := range violating {
+			impact.Proposals = append(impact.Proposals, sch.propose(workId, parts))
+		}
+		sort.Slice(impact.Proposals, func(i, j int) bool {
+			return impact.Proposals[i].WorkId < impact.Proposals[j].WorkId
+		})
+	}
+	return
+}
+
+func (sch *Scheduler) violations(zonesSchedule Schedule, wi *models.WorkItem) (violations []Violation) {
+	critical := wi.Priority == string(PriorityCritical)
+	for _, zone := range wi.Zones {
+		available, err := sch.checkZoneLists(zone, wi)
+		if err != nil && !critical {
+			rule := RuleWhiteList
+			if slices.Contains(sch.Config.At(wi.StartDate).BlackList, zone) {
+				rule = RuleBlackList
+			}
+			violations = append(violations, Violation{WorkId: wi.WorkId, Zone: zone, Rule: rule, Message: err.Error()})
+		} else if err == nil && !available && !critical {
+			violations = append(violations, Violation{WorkId: wi.WorkId, Zone: zone, Rule: RuleWhiteList,
+				Message: fmt.Sprintf("work from %s for %d minutes is out of zone %s white-list windows", wi.StartDate.Format(time.RFC3339), wi.DurationMinutes, zone)})
+		}
+	}
+
+	maxDuration := sch.limits(wi).MaxWorkDurationMinutes
+	if !critical && wi.DurationMinutes > maxDuration {
+		violations = append(violations, Violation{WorkId: wi.WorkId, Rule: RuleMaxDuration,
+			Message: fmt.Sprintf("%s work duration %d minutes is greater then %d minutes", wi.WorkType, wi.DurationMinutes, maxDuration)})
+	}
+
+	span, err := getWorkInterval(wi)
+	if err != nil {
+		return
+	}
+	if ok, availableInZones := sch.checkMinAvailableZones(zonesSchedule.scheduleByZones, span); !ok {
+		violations = append(violations, Violation{WorkId: wi.WorkId, Rule: RuleMinAvialableZones,
+			Message: fmt.Sprintf("only %d zones are available during work, required %d", len(availableInZones), sch.Config.MinAvialableZonesBetween(span.Start(), span.End()))})
+	}
+	return
+}
+
+func (sch *Scheduler) propose(workId string, parts []*models.WorkItem) (proposal Proposal) {
+	proposal.WorkId = workId
+	schedule, userMustApprove, err := sch.MoveWork(parts)
+	if err != nil {
+		proposal.Error = err.Error()
+		return
+	}
+	proposal.Schedule = schedule
+	proposal.UserMustApprove = userMustApprove
+	return
+}
+
+// logImpact reports works which become illegal after config reload.
+func (sch *Scheduler) logImpact(conf *configuration.Config) {
+	impact, err := sch.Impact(conf, time.Now(), false)
+	if err != nil {
+		log.Printf("WARNING: Find error while checking impact of config version %d, %s\n", conf.Version, err)
+		return
+	}
+	for _, v := range impact.Violations {
+		log.Printf("WARNING: config version %d violates %s for work %s: %s\n", conf.Version, v.Rule, v.WorkId, v.Message)
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+	"workScheduler/internal/configuration"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
+	"workScheduler/internal/scheduler/models"
+)
+
+func TestImpact(t *testing.T) {
+
+	t.Run("succees find works violating new config", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		now := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+		allDay := []configuration.Window{{StartHour: 0, EndHour: 24}}
+		live := &configuration.Config{
+			WhiteList:              map[string][]configuration.Window{"Zone_1": allDay, "Zone_2": allDay, "Zone_3": allDay, "Zone_4": allDay},
+			MinAvialableZones:      1,
+			MaxWorkDurationMinutes: configuration.WorkDurationSettings{Automatic: 360, Manual: 360},
+			MaxDeadlineDays:        28,
+		}
+
+		repo := inmemoryrepository.NewInmemoryRepository()
+		for _, work := range []*models.WorkItem{
+			{WorkId: "blacklisted", Zones: []string{"Zone_1"}},
+			{WorkId: "out_of_window", Zones: []string{"Zone_2"}},
+			{WorkId: "allowed", Zones: []string{"Zone_3"}},
+		} {
+			work.StartDate = now.Add(10 * time.Hour)
+			work.DurationMinutes = 60
+			work.Deadline = now.Add(48 * time.Hour)
+			work.WorkType = "manual"
+			work.Priority = "regular"
+			work.Status = models.StatusPlanned
+			repo.Add(ctx, work)
+		}
+		scheduler := NewScheduler(ctx, repo, configuration.NewStaticConfigurator(live))
+
+		candidate := &configuration.Config{
+			WhiteList:              map[string][]configuration.Window{"Zone_2": {{StartHour: 0, EndHour: 6}}, "Zone_3": allDay, "Zone_4": allDay},
+			BlackList:              []string{"Zone_1"},
+			MinAvialableZones:      1,
+			MaxWorkDurationMinutes: configuration.WorkDurationSettings{Automatic: 360, Manual: 360},
+			MaxDeadlineDays:        28,
+		}
+		impact, err := scheduler.Impact(candidate, now, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(impact.Violations) != 2 ||
+			impact.Violations[0].WorkId != "blacklisted" || impact.Violations[0].Rule != RuleBlackList ||
+			impact.Violations[1].WorkId != "out_of_window" || impact.Violations[1].Rule != RuleWhiteList {
+			t.Errorf("unexpected violations: %+v", impact.Violations)
+		}
+		if len(impact.Proposals) != 2 || impact.Proposals[0].Error == "" {
+			t.Errorf("unexpected proposals: %+v", impact.Proposals)
+		}
+		// proposals are not saved
+		if stored, _ := repo.GetById(ctx, "out_of_window"); len(stored) != 1 || !stored[0].StartDate.Equal(now.Add(10*time.Hour)) {
+			t.Errorf("unexpected stored work after proposal: %v", stored)
+		}
+
+		if impact, err = scheduler.Impact(live, now, false); err != nil || len(impact.Violations) != 0 {
+			t.Errorf("unexpected impact of live config: %+v %v", impact, err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"time"
+
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/scheduler/models"
+)
+
+// limits returns limits of work under the decision config in effect at the work start.
+func (sch *Scheduler) limits(wi *models.WorkItem) configuration.Limits {
+	return sch.Config.At(wi.StartDate).Limits(wi.Zones, wi.WorkType, wi.Priority)
+}
+
+// horizon is the latest time work may be scheduled to, by its deadline limit.
+func (sch *Scheduler) horizon(wi *models.WorkItem) time.Time {
+	return wi.StartDate.Add(24 * time.Hour * time.Duration(sch.limits(wi).MaxDeadlineDays)).Add(time.Minute * time.Duration(-1*wi.DurationMinutes))
+}
+
+// compressToWindows compresses regular automatic work which overruns white-list windows to end
+// within the window it starts in, in every zone of the work. The work is compressed once on a copy
+// to the earliest window end among zones, bounded by the strictest limits of its zones, and is
+// changed only if it fits all zones then.
+func (sch *Scheduler) compressToWindows(wi *models.WorkItem) bool {
+	if wi.WorkType != WorkTypeAutomatic || wi.Priority == PriorityCritical || len(wi.Zones) == 0 {
+		return false
+	}
+	limits := sch.limits(wi)
+	if limits.CompressionRate >= 1 || wi.InitialDuration == 0 {
+		return false
+	}
+	fits := func(work *models.WorkItem) bool {
+		for _, zone := range work.Zones {
+			zoneWork := *work
+			zoneWork.Zones = []string{zone}
+			if available, err := sch.checkZoneLists(zone, &zoneWork); err != nil || !available {
+				return false
+			}
+		}
+		return true
+	}
+	if fits(wi) {
+		return false
+	}
+
+	conf := sch.Config.At(wi.StartDate)
+	day := time.Date(wi.StartDate.Year(), wi.StartDate.Month(), wi.StartDate.Day(), 0, 0, 0, 0, wi.StartDate.Location())
+	var end time.Time
+	for _, zone := range wi.Zones {
+		windows, _ := conf.Windows(zone, wi.WorkType, wi.Priority)
+		var zoneEnd time.Time
+		for _, window := range windows {
+			start := day.Add(time.Duration(window.StartHour) * time.Hour)
+			windowEnd := day.Add(time.Duration(window.EndHour) * time.Hour)
+			if !wi.StartDate.Before(start) && wi.StartDate.Before(windowEnd) {
+				zoneEnd = windowEnd
+				break
+			}
+		}
+		// work starting out of the zone windows is moved, not compressed
+		if zoneEnd.IsZero() {
+			return false
+		}
+		if end.IsZero() || zoneEnd.Before(end) {
+			end = zoneEnd
+		}
+	}
+
+	compressed := *wi
+	if compressed.CompressionRate == 0 {
+		compressed.CompressionRate = 1
+	}
+	if !compressed.CompressFromEnd(limits.CompressionRate, end, limits.MinWorkDurationMinutes) || !fits(&compressed) {
+		return false
+	}
+	wi.DurationMinutes, wi.CompressionRate = compressed.DurationMinutes, compressed.CompressionRate
+	return true
+}
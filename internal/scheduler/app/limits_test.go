@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+	"workScheduler/internal/configuration"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
+	"workScheduler/internal/scheduler/models"
+)
+
+func TestCompressToWindow(t *testing.T) {
+
+	t.Run("succees compress automatic work by zone limits", func(t *testing.T) {
+		maxDuration := int32(600)
+		conf := &configuration.Config{
+			WhiteList:               map[string][]configuration.Window{"storage": {{StartHour: 6, EndHour: 18}}, "network": {{StartHour: 6, EndHour: 18}}},
+			MinWorkDurationMinutes:  configuration.WorkDurationSettings{Automatic: 5, Manual: 30},
+			MaxWorkDurationMinutes:  configuration.WorkDurationSettings{Automatic: 360, Manual: 360},
+			MaxDeadlineDays:         28,
+			TimeCompressionPercents: "10%",
+			TimeCompressionRate:     0.9,
+			LimitsOverrides: []configuration.LimitsOverride{
+				{Zones: []string{"storage"}, MaxWorkDurationMinutes: &maxDuration, TimeCompressionPercents: "50%", TimeCompressionRate: 0.5},
+			},
+		}
+		scheduler := NewScheduler(context.Background(), inmemoryrepository.NewInmemoryRepository(), configuration.NewStaticConfigurator(conf)).decision()
+		day := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+
+		// 5 hours from 15:00 overrun the window by 2 hours, storage allows to compress it to 3 hours
+		for zone, compressed := range map[string]bool{"storage": true, "network": false} {
+			wi := &models.WorkItem{WorkId: zone, Zones: []string{zone}, WorkType: WorkTypeAutomatic, Priority: PriorityRegular,
+				StartDate: day.Add(15 * time.Hour), DurationMinutes: 300, InitialDuration: 300, CompressionRate: 1}
+			if ok := scheduler.compressToWindows(wi); ok != compressed {
+				t.Errorf("unexpected compression in %s: %v", zone, ok)
+			}
+			if compressed && (wi.EndTime().After(day.Add(18*time.Hour)) || wi.DurationMinutes < 150) {
+				t.Errorf("unexpected compressed work in %s: %v minutes", zone, wi.DurationMinutes)
+			}
+			if !compressed && wi.DurationMinutes != 300 {
+				t.Errorf("work in %s is changed: %v minutes", zone, wi.DurationMinutes)
+			}
+		}
+	})
+
+	t.Run("succees compress multi-zone work once by the strictest zone", func(t *testing.T) {
+		conf := &configuration.Config{
+			WhiteList:               map[string][]configuration.Window{"storage": {{StartHour: 6, EndHour: 18}}, "backup": {{StartHour: 6, EndHour: 17}}},
+			MinWorkDurationMinutes:  configuration.WorkDurationSettings{Automatic: 5, Manual: 30},
+			MaxWorkDurationMinutes:  configuration.WorkDurationSettings{Automatic: 360, Manual: 360},
+			MaxDeadlineDays:         28,
+			TimeCompressionPercents: "10%",
+			TimeCompressionRate:     0.9,
+			LimitsOverrides: []configuration.LimitsOverride{
+				{Zones: []string{"storage"}, TimeCompressionPercents: "50%", TimeCompressionRate: 0.5},
+				{Zones: []string{"backup"}, TimeCompressionPercents: "20%", TimeCompressionRate: 0.8},
+			},
+		}
+		scheduler := NewScheduler(context.Background(), inmemoryrepository.NewInmemoryRepository(), configuration.NewStaticConfigurator(conf)).decision()
+		day := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+
+		// backup window ends first and backup allows to compress by 20% only
+		for duration, compressed := range map[int32]bool{140: true, 200: false} {
+			wi := &models.WorkItem{WorkId: "work", Zones: []string{"backup", "storage"}, WorkType: WorkTypeAutomatic, Priority: PriorityRegular,
+				StartDate: day.Add(15 * time.Hour), DurationMinutes: duration, InitialDuration: duration, CompressionRate: 1}
+			if ok := scheduler.compressToWindows(wi); ok != compressed {
+				t.Errorf("unexpected compression of %d minutes: %v", duration, ok)
+			}
+			if compressed && (wi.EndTime().After(day.Add(17*time.Hour)) || wi.CompressionRate < 0.8 || wi.DurationMinutes < 110) {
+				t.Errorf("unexpected compressed work of %d minutes: %v minutes, rate %v", duration, wi.DurationMinutes, wi.CompressionRate)
+			}
+			if !compressed && (wi.DurationMinutes != duration || wi.CompressionRate != 1) {
+				t.Errorf("work of %d minutes is changed: %v minutes", duration, wi.DurationMinutes)
+			}
+		}
+	})
+}
+
+func TestWorkTypeWindows(t *testing.T) {
+
+	t.Run("succees check zone windows of work type", func(t *testing.T) {
+		conf := &configuration.Config{
+			WhiteList: map[string][]configuration.Window{"zone1": {{StartHour: 9, EndHour: 18}}},
+			WhiteListOverrides: []configuration.WindowsOverride{
+				{WorkType: WorkTypeAutomatic, Windows: []configuration.Window{{StartHour: 0, EndHour: 6}}},
+			},
+		}
+		scheduler := NewScheduler(context.Background(), inmemoryrepository.NewInmemoryRepository(), configuration.NewStaticConfigurator(conf)).decision()
+		day := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+
+		for _, c := range []struct {
+			workType string
+			hour     int
+			want     bool
+		}{
+			{WorkTypeAutomatic, 2, true},
+			{WorkTypeAutomatic, 10, false},
+			{WorkTypeManual, 10, true},
+			{WorkTypeManual, 2, false},
+		} {
+			wi := &models.WorkItem{Zones: []string{"zone1"}, WorkType: c.workType, Priority: PriorityRegular,
+				StartDate: day.Add(time.Duration(c.hour) * time.Hour), DurationMinutes: 60}
+			if available, err := scheduler.checkZoneLists("zone1", wi); err != nil || available != c.want {
+				t.Errorf("unexpected availability of %s work at %d: %v %v", c.workType, c.hour, available, err)
+			}
+		}
+	})
+}
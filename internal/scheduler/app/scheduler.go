@@ -3,7 +3,9 @@ package app
 import (
 	"context"
 	"fmt"
+	"log"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"workScheduler/internal/configuration"
@@ -20,15 +22,26 @@ const (
 	PriorityRegular   = "regular"
 	WorkTypeAutomatic = "automatic"
 	WorkTypeManual    = "manual"
-	StatusInProgress  = "in_progress"
-	StatusPlanned     = "planned"
-	Statuscanceled    = "canceled"
+	StatusInProgress  = models.StatusInProgress
+	StatusPlanned     = models.StatusPlanned
+	Statuscanceled    = models.StatusCanceled
+	StatusCompleted   = models.StatusCompleted
+	// StatusOverdue is set to manual works not finished by their planned end, they block zones until finish.
+	StatusOverdue = models.StatusOverdue
+	StatusHeld    = models.StatusHeld
+	// outcomes reported by executor, work is finished with reason
+	StatusFailed     = models.StatusFailed
+	StatusRolledBack = models.StatusRolledBack
+	StatusAborted    = models.StatusAborted
 )
 
 type Scheduler struct {
+	// Config is the snapshot used by a scheduling decision, see decision
 	Config     *configuration.Config
 	Repository repository.ReadRepository
 	ctx        context.Context
+	// latest config received from configurator subscription
+	latest *atomic.Pointer[configuration.Config]
 }
 
 type Schedule struct {
@@ -46,21 +59,76 @@ func getWorkInterval(wi *models.WorkItem) (span *interval.Span, err error) {
 	return
 }
 
+func getOverdueInterval(wi *models.WorkItem, to time.Time) (span *interval.Span, err error) {
+	workInt, err := interval.New(wi.StartDate, to)
+	span = &workInt
+	return
+}
+
+// markPlanned sets planned status to new or moved work, started works keep their status on prolongation
+// and held works keep their hold.
+func markPlanned(wi *models.WorkItem) error {
+	if wi.Status == StatusInProgress || wi.Status == StatusOverdue || wi.Status == StatusHeld {
+		return nil
+	}
+	return wi.ChangeStatus(StatusPlanned, models.ActorScheduler)
+}
+
 func NewScheduler(ctx context.Context, repository repository.ReadRepository, config *configuration.Configurator) (scheduler *Scheduler) {
 	scheduler = &Scheduler{
 		Repository: repository,
-		Config:     config.Data,
+		Config:     config.Current(),
 		ctx:        ctx,
+		latest:     &atomic.Pointer[configuration.Config]{},
 	}
+	scheduler.latest.Store(config.Current())
+	go scheduler.follow(config.Subscribe())
 	return
 }
 
+func (sch *Scheduler) follow(configs <-chan *configuration.Config) {
+	for {
+		select {
+		case <-sch.ctx.Done():
+			return
+		case conf := <-configs:
+			if conf == sch.latest.Load() {
+				continue
+			}
+			sch.latest.Store(conf)
+			log.Printf("successfully switched scheduler to config version %d\n", conf.Version)
+			// reloaded config may make already planned works illegal
+			sch.logImpact(conf)
+		}
+	}
+}
+
+// decision returns scheduler reading the same config snapshot during the whole decision, even if
+// config is reloaded meanwhile.
+func (sch *Scheduler) decision() *Scheduler {
+	return &Scheduler{
+		Config:     sch.latest.Load(),
+		Repository: sch.Repository,
+		ctx:        sch.ctx,
+		latest:     sch.latest,
+	}
+}
+
+// stamp records config version used for the decision in scheduled works.
+func (sch *Scheduler) stamp(schedule []*models.WorkItem) {
+	for _, wi := range schedule {
+		wi.ConfigVersion = sch.Config.Version
+	}
+}
+
 func (sch *Scheduler) MoveWork(wis []*models.WorkItem) (schedule []*models.WorkItem, userMustApprove bool, err error) {
+	sch = sch.decision()
+	defer func() { sch.stamp(schedule) }()
 	sort.Slice(wis, func(i, j int) bool {
 		return wis[i].StartDate.Before(wis[j].StartDate)
 	})
-	from := wis[0].StartDate.Add(time.Minute * time.Duration(-1*Max(sch.Config.MaxWorkDurationMinutes.Automatic, sch.Config.MaxWorkDurationMinutes.Manual)))
-	to := wis[len(wis)-1].StartDate.Add(24 * time.Hour * time.Duration(sch.Config.MaxDeadlineDays)).Add(time.Minute * time.Duration(-1*(wis[len(wis)-1].DurationMinutes)))
+	from := wis[0].StartDate.Add(time.Minute * time.Duration(-1*sch.Config.LongestWorkMinutes()))
+	to := sch.horizon(wis[len(wis)-1])
 
 	// текущее расписание
 	allZonesSchedule, err := sch.getAllZonesSchedule(from, to)
@@ -96,9 +164,11 @@ func (sch *Scheduler) MoveWork(wis []*models.WorkItem) (schedule []*models.WorkI
 }
 
 func (sch *Scheduler) ScheduleWork(wi *models.WorkItem) (schedule []*models.WorkItem, userMustApprove bool, err error) {
+	sch = sch.decision()
+	defer func() { sch.stamp(schedule) }()
 	userMustApprove = false
-	from := wi.StartDate.Add(time.Minute * time.Duration(-1*Max(sch.Config.MaxWorkDurationMinutes.Automatic, sch.Config.MaxWorkDurationMinutes.Manual)))
-	to := wi.StartDate.Add(24 * time.Hour * time.Duration(sch.Config.MaxDeadlineDays)).Add(time.Minute * time.Duration(-1*wi.DurationMinutes))
+	from := wi.StartDate.Add(time.Minute * time.Duration(-1*sch.Config.LongestWorkMinutes()))
+	to := sch.horizon(wi)
 
 	// текущее расписание
 	allZonesSchedule, err := sch.getAllZonesSchedule(from, to)
@@ -127,12 +197,18 @@ func (sch *Scheduler) ScheduleWork(wi *models.WorkItem) (schedule []*models.Work
 }
 
 func (sch *Scheduler) ProlongateWorkById(wis []*models.WorkItem) (schedule []*models.WorkItem, userMustApprove bool, err error) {
+	sch = sch.decision()
+	defer func() { sch.stamp(schedule) }()
 	userMustApprove = true
 	sort.Slice(wis, func(i, j int) bool {
 		return wis[i].StartDate.Before(wis[j].StartDate)
 	})
-	from := wis[0].StartDate.Add(time.Minute * time.Duration(-1*Max(sch.Config.MaxWorkDurationMinutes.Automatic, sch.Config.MaxWorkDurationMinutes.Manual)))
-	to := wis[len(wis)-1].StartDate.Add(24 * time.Hour * time.Duration(sch.Config.MaxDeadlineDays)).Add(time.Minute * time.Duration(-1*(wis[len(wis)-1].DurationMinutes)))
+	from := wis[0].StartDate.Add(time.Minute * time.Duration(-1*sch.Config.LongestWorkMinutes()))
+	to := sch.horizon(wis[len(wis)-1])
+	starts := map[string]time.Time{}
+	for _, wi := range wis {
+		starts[wi.Id.Hex()] = wi.StartDate
+	}
 
 	// текущее расписание
 	allZonesSchedule, err := sch.getAllZonesSchedule(from, to)
@@ -156,6 +232,16 @@ func (sch *Scheduler) ProlongateWorkById(wis []*models.WorkItem) (schedule []*mo
 			schedule = append(schedule, newSchedule...)
 		}
 	}
+	// prolongation which keeps works in place and doesn't touch other works needs no approval
+	if err == nil && len(schedule) == len(wis) {
+		inPlace := true
+		for _, s := range schedule {
+			if start, ok := starts[s.Id.Hex()]; !ok || !start.Equal(s.StartDate) {
+				inPlace = false
+			}
+		}
+		userMustApprove = !inPlace
+	}
 	return
 }
 
@@ -172,6 +258,13 @@ func (sch *Scheduler) chekScheduleChange(zonesSchedule Schedule, wi *models.Work
 	sort.Slice(wi.Zones, func(i, j int) bool {
 		return wi.Zones[i] < wi.Zones[j]
 	})
+	// не попадающие по времени в white лист автоматические работы сначала пробуем сжать до конца окна во всех зонах
+	if sch.compressToWindows(wi) {
+		if workItemInterval, err = getWorkInterval(wi); err != nil {
+			return
+		}
+		userMustApprove = true
+	}
 	for zi, z := range wi.Zones {
 		scheduleWIZone := []*models.WorkItem{}
 		wiCopyForThisZ := *wi
@@ -184,7 +277,6 @@ func (sch *Scheduler) chekScheduleChange(zonesSchedule Schedule, wi *models.Work
 			err = zoneErr
 			return
 		}
-		// не попали по времени в white лист
 		var minStartDate = wiCopyForThisZ.StartDate
 		if !available {
 			date, winErr := sch.getNearestZoneWindowStart(z, &wiCopyForThisZ)
@@ -216,7 +308,9 @@ func (sch *Scheduler) chekScheduleChange(zonesSchedule Schedule, wi *models.Work
 				}
 				change := wi
 				change.StartDate = startTime
-				change.Status = StatusPlanned
+				if err = markPlanned(change); err != nil {
+					return
+				}
 				schedule = append(schedule, change)
 				return
 			}
@@ -251,7 +345,9 @@ func (sch *Scheduler) chekScheduleChange(zonesSchedule Schedule, wi *models.Work
 				sort.Slice(tryWi.Zones, func(i, j int) bool {
 					return tryWi.Zones[i] < tryWi.Zones[j]
 				})
-				tryWi.Status = StatusPlanned
+				if err = markPlanned(&tryWi); err != nil {
+					return
+				}
 				span, _ := getWorkInterval(&tryWi)
 				startTime, _, moveErr := sch.moveToNextAvailable(zonesSchedule.scheduleByZones, z, &tryWi)
 				if moveErr == nil && startTime == tryWi.StartDate {
@@ -277,7 +373,9 @@ func (sch *Scheduler) chekScheduleChange(zonesSchedule Schedule, wi *models.Work
 				change := &sugestedWi
 				change.StartDate = startTime
 				change.Zones = []string{z}
-				change.Status = StatusPlanned
+				if err = markPlanned(change); err != nil {
+					return
+				}
 				scheduleWIZone = append(scheduleWIZone, change)
 
 				span, _ := getWorkInterval(change)
@@ -361,11 +459,29 @@ func merge(source []*IntervalWork, new []*models.WorkItem) []*IntervalWork {
 }
 
 func (sch *Scheduler) getAllZonesSchedule(from time.Time, to time.Time) (zoneSchedules Schedule, err error) {
-	statuses := []string{StatusPlanned, StatusInProgress}
-	works, err := sch.Repository.List(sch.ctx, from, to, []string{}, statuses)
+	statuses := []string{string(StatusPlanned), string(StatusInProgress), string(StatusHeld)}
+	listed, err := sch.Repository.List(sch.ctx, from, to, []string{}, statuses, repository.ListModeOverlaps)
 	if err != nil {
 		return
 	}
+	// expired holds are free even before they are released
+	now := time.Now()
+	works := []*models.WorkItem{}
+	for _, w := range listed {
+		if w.Status != StatusHeld || now.Before(w.HoldUntil) {
+			works = append(works, w)
+		}
+	}
+	// overdue works are not finished yet, so they block their zones regardless of the planned end
+	overdue, err := sch.Repository.List(sch.ctx, time.Unix(0, 0), to, []string{}, []string{string(StatusOverdue)}, repository.ListModeOverlaps)
+	if err != nil {
+		return
+	}
+	for _, w := range overdue {
+		if w.Status == StatusOverdue {
+			works = append(works, w)
+		}
+	}
 	sort.Slice(works, func(i, j int) bool {
 		return works[i].StartDate.Before(works[j].StartDate)
 	})
@@ -379,6 +495,13 @@ func (sch *Scheduler) getAllZonesSchedule(from time.Time, to time.Time) (zoneSch
 			err = intErr
 			return
 		}
+		if w.Status == StatusOverdue && interval.End().Before(to) {
+			interval, intErr = getOverdueInterval(w, to)
+			if intErr != nil {
+				err = intErr
+				return
+			}
+		}
 		iw := IntervalWork{
 			Span: interval,
 			Work: w,
@@ -405,7 +528,8 @@ func (sch *Scheduler) checkMinAvailableZones(allZonesSchedule map[string][]*Inte
 			availableInZones = append(availableInZones, z)
 		}
 	}
-	ok = availableCount >= int(sch.Config.MinAvialableZones)
+	// requirement may change during work, the strictest one is checked
+	ok = availableCount >= int(sch.Config.MinAvialableZonesBetween(workItemInterval.Start(), workItemInterval.End()))
 	return
 }
 
@@ -500,11 +624,9 @@ func moveOrCancelOthers(zoneSchedule []*IntervalWork, checkInterval interval.Spa
 	for _, interv := range zoneSchedule {
 		if interv.Span.IsIntersection(checkInterval) {
 			if (cancelManual && interv.Work.WorkType == WorkTypeManual) || (cancelAuto && interv.Work.WorkType == WorkTypeAutomatic) {
-				if interv.Work.Status == StatusInProgress {
-					err = fmt.Errorf("unable to cancel in progress work %v", interv.Work.WorkId)
+				if err = interv.Work.ChangeStatus(Statuscanceled, models.ActorScheduler); err != nil {
 					return
 				} else {
-					interv.Work.Status = Statuscanceled
 					changes = append(changes, interv.Work)
 					break
 					// todo переносить или сжимать автоматические вместо отмены
@@ -536,7 +658,12 @@ func createMovement(zoneSchedule []*IntervalWork, checkInterval interval.Span) (
 				return
 			}
 			last_interval = *inter.Span
-			inter.Work.Status = StatusPlanned
+			// held works are moved with their hold
+			if inter.Work.Status != StatusHeld {
+				if err = inter.Work.ChangeStatus(StatusPlanned, models.ActorScheduler); err != nil {
+					return
+				}
+			}
 			changes = append(changes, inter.Work)
 		} else {
 			break
@@ -546,13 +673,15 @@ func createMovement(zoneSchedule []*IntervalWork, checkInterval interval.Span) (
 }
 
 func (sch *Scheduler) checkZoneLists(zone string, wi *models.WorkItem) (availavle bool, err error) {
+	// работа проверяется по правилам, которые будут действовать на момент ее начала
+	conf := sch.Config.At(wi.StartDate)
 	// проверяем, если зона в блеклисте && работы != критичные -> 500 возвращаем полную невозможность - err
-	if slices.Contains(sch.Config.BlackList, zone) && wi.Priority != string(PriorityCritical) {
+	if slices.Contains(conf.BlackList, zone) && wi.Priority != string(PriorityCritical) {
 		err = fmt.Errorf("zone %v is in black list, unable to Schedule work with non-critical priority", zone)
 		return
 	}
 	// проверяем, если зона в вайт листе && работы не в окне -> 500 возвращаем невозможность c вариантами сдвига
-	windows, ok := sch.Config.WhiteList[zone]
+	windows, ok := conf.Windows(zone, wi.WorkType, wi.Priority)
 	if !ok {
 		err = fmt.Errorf("zone %v not found in zone white-list", zone)
 		return
@@ -614,7 +743,7 @@ func (sch *Scheduler) checkZoneLists(zone string, wi *models.WorkItem) (availavl
 }
 
 func (sch *Scheduler) getNearestZoneWindowStart(zone string, wi *models.WorkItem) (start time.Time, err error) {
-	windows, ok := sch.Config.WhiteList[zone]
+	windows, ok := sch.Config.At(wi.StartDate).Windows(zone, wi.WorkType, wi.Priority)
 	if !ok {
 		err = fmt.Errorf("zone %v not found in zone white-list", zone)
 		return
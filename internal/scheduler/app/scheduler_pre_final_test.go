@@ -365,7 +365,7 @@ func TestScheduleEvents(t *testing.T) {
 			case "prolongate":
 				result, userMustApprove, err = scheduler.MoveWork([]*models.WorkItem{e.NewWork})
 			case "config change":
-				e.ConfigChange(c.Data)
+				e.ConfigChange(c.Current())
 			default:
 				t.Fatalf("%s: unexpected event action\n", e.Name)
 			}
@@ -28,10 +28,13 @@ func (r RepositoryMock) GetById(ctx context.Context, id string) (mod []*models.W
 	}
 	return
 }
-func (r RepositoryMock) List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string) (mod []*models.WorkItem, err error) {
+func (r RepositoryMock) List(ctx context.Context, from time.Time, to time.Time, zones []string, statuses []string, mode repository.ListMode) (mod []*models.WorkItem, err error) {
 	mod = r.ListResult
 	return
 }
+func (r RepositoryMock) ListPage(ctx context.Context, query repository.PageQuery) (repository.Page, error) {
+	return repository.PageOf(r.ListResult, query)
+}
 
 func TestScheduleWorkSuccees(t *testing.T) {
 
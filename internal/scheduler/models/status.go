@@ -0,0 +1,134 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+)
+
+// Status is a work lifecycle status, it is changed only by WorkItem.ChangeStatus.
+type Status string
+
+const (
+	// StatusHeld is a tentative reservation of slot until HoldUntil, it is released unless confirmed
+	StatusHeld       Status = "held"
+	StatusPlanned    Status = "planned"
+	StatusInProgress Status = "in_progress"
+	StatusOverdue    Status = "overdue"
+	StatusCompleted  Status = "completed"
+	StatusCanceled   Status = "canceled"
+	StatusFailed     Status = "failed"
+	StatusRolledBack Status = "rolled_back"
+	StatusAborted    Status = "aborted"
+)
+
+// Statuses are all known statuses, storages reject others: mongo and postgres by schema
+// validation, memory and embedded storages by ValidateStatus.
+var Statuses = []Status{StatusHeld, StatusPlanned, StatusInProgress, StatusOverdue, StatusCompleted, StatusCanceled, StatusFailed, StatusRolledBack, StatusAborted}
+
+// ValidateStatus returns an error if work status is not one of Statuses.
+func (w *WorkItem) ValidateStatus() error {
+	if !slices.Contains(Statuses, w.Status) {
+		return fmt.Errorf("unknown status %q of work %s", w.Status, w.WorkId)
+	}
+	return nil
+}
+
+// Actor is who changes work status.
+type Actor string
+
+const (
+	// ActorUser is an engineer calling API
+	ActorUser Actor = "user"
+	// ActorScheduler plans new works and cancels or moves others for them
+	ActorScheduler Actor = "scheduler"
+	// ActorActualizer changes statuses by time
+	ActorActualizer Actor = "actualizer"
+	// ActorExecutor reports outcome of work
+	ActorExecutor Actor = "executor"
+	// ActorReleaser releases expired holds
+	ActorReleaser Actor = "releaser"
+)
+
+type Transition struct {
+	From Status
+	To   Status
+}
+
+// Transitions are allowed status changes and actors which may make them. New works have
+// empty status.
+var Transitions = map[Transition][]Actor{
+	{"", StatusPlanned}: {ActorScheduler, ActorUser},
+	{"", StatusHeld}:    {ActorUser},
+
+	// confirmation of hold
+	{StatusHeld, StatusPlanned}:  {ActorUser},
+	{StatusHeld, StatusCanceled}: {ActorUser, ActorScheduler, ActorReleaser},
+
+	{StatusPlanned, StatusInProgress}: {ActorActualizer, ActorUser},
+	{StatusPlanned, StatusCanceled}:   {ActorUser, ActorScheduler},
+	// automatic work missed while service was down
+	{StatusPlanned, StatusCompleted}: {ActorActualizer},
+	{StatusPlanned, StatusOverdue}:   {ActorActualizer},
+
+	{StatusInProgress, StatusCompleted}:  {ActorActualizer, ActorUser, ActorExecutor},
+	{StatusInProgress, StatusOverdue}:    {ActorActualizer},
+	{StatusInProgress, StatusFailed}:     {ActorExecutor},
+	{StatusInProgress, StatusRolledBack}: {ActorExecutor},
+	{StatusInProgress, StatusAborted}:    {ActorExecutor},
+
+	// prolongation of overdue work
+	{StatusOverdue, StatusInProgress}: {ActorUser},
+	{StatusOverdue, StatusCompleted}:  {ActorUser, ActorExecutor},
+	{StatusOverdue, StatusFailed}:     {ActorExecutor},
+	{StatusOverdue, StatusRolledBack}: {ActorExecutor},
+	{StatusOverdue, StatusAborted}:    {ActorExecutor},
+
+	// automatic work is completed by actualizer before executor reports outcome
+	{StatusCompleted, StatusFailed}:     {ActorExecutor},
+	{StatusCompleted, StatusRolledBack}: {ActorExecutor},
+	{StatusCompleted, StatusAborted}:    {ActorExecutor},
+}
+
+// ErrorIllegalTransition is returned when actor may not change work status.
+type ErrorIllegalTransition struct {
+	WorkId string
+	From   Status
+	To     Status
+	Actor  Actor
+}
+
+func (e *ErrorIllegalTransition) Error() string {
+	from := e.From
+	if from == "" {
+		from = "new"
+	}
+	return fmt.Sprintf("%s can't change status of work %s from %s to %s", e.Actor, e.WorkId, from, e.To)
+}
+
+func IsIllegalTransition(err error) bool {
+	var e *ErrorIllegalTransition
+	return errors.As(err, &e)
+}
+
+// CanChangeStatus checks that actor may change work status to the given one, keeping the same
+// status is always allowed.
+func (w *WorkItem) CanChangeStatus(to Status, actor Actor) error {
+	if w.Status == to {
+		return nil
+	}
+	if !slices.Contains(Transitions[Transition{From: w.Status, To: to}], actor) {
+		return &ErrorIllegalTransition{WorkId: w.WorkId, From: w.Status, To: to, Actor: actor}
+	}
+	return nil
+}
+
+// ChangeStatus sets work status if the transition is allowed for actor.
+func (w *WorkItem) ChangeStatus(to Status, actor Actor) error {
+	if err := w.CanChangeStatus(to, actor); err != nil {
+		return err
+	}
+	w.Status = to
+	return nil
+}
@@ -0,0 +1,51 @@
+package models
+
+import "testing"
+
+func TestChangeStatus(t *testing.T) {
+
+	t.Run("succees allowed transitions", func(t *testing.T) {
+		work := &WorkItem{WorkId: "1"}
+		steps := []struct {
+			to    Status
+			actor Actor
+		}{
+			{StatusPlanned, ActorScheduler},
+			{StatusInProgress, ActorActualizer},
+			{StatusCompleted, ActorActualizer},
+			{StatusRolledBack, ActorExecutor},
+		}
+		for _, step := range steps {
+			if err := work.ChangeStatus(step.to, step.actor); err != nil {
+				t.Fatalf("unexpected error on change to %s by %s: %v", step.to, step.actor, err)
+			}
+		}
+		if work.Status != StatusRolledBack {
+			t.Errorf("unexpected status: %v", work.Status)
+		}
+	})
+
+	t.Run("succees illegal transitions", func(t *testing.T) {
+		for _, tt := range []struct {
+			from  Status
+			to    Status
+			actor Actor
+		}{
+			{StatusCompleted, StatusCanceled, ActorUser},
+			{StatusInProgress, StatusCanceled, ActorUser},
+			{StatusInProgress, StatusCanceled, ActorScheduler},
+			{StatusPlanned, StatusFailed, ActorExecutor},
+			{StatusCanceled, StatusPlanned, ActorScheduler},
+			{StatusPlanned, StatusCompleted, ActorUser},
+		} {
+			work := &WorkItem{WorkId: "1", Status: tt.from}
+			err := work.ChangeStatus(tt.to, tt.actor)
+			if !IsIllegalTransition(err) {
+				t.Errorf("unexpected error on change from %s to %s by %s: %v", tt.from, tt.to, tt.actor, err)
+			}
+			if work.Status != tt.from {
+				t.Errorf("status is changed by illegal transition: %v", work.Status)
+			}
+		}
+	})
+}
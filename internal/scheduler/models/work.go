@@ -15,14 +15,51 @@ type WorkItem struct {
 	WorkId           string             `bson:"workId,omitempty" json:"workId"`
 	Priority         string             `bson:"priority,omitempty" json:"priority"`
 	StartDate        time.Time          `bson:"startDate,omitempty" json:"startDate"`
-	Status           string             `bson:"status,omitempty" json:"status"`
+	EndDate          time.Time          `bson:"endDate,omitempty" json:"-"`
+	Status           Status             `bson:"status,omitempty" json:"status"`
+	Reason           string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	HoldUntil        time.Time          `bson:"holdUntil,omitempty" json:"holdUntil"`
 	WorkType         string             `bson:"workType,omitempty" json:"workType"`
+	Team             string             `bson:"team,omitempty" json:"team,omitempty"`
 	Zones            []string           `bson:"zones,omitempty" json:"zones"`
 	CompressionRate  float32            `bson:"compressionRate,omitempty" json:"-"`
 	InitialDuration  int32              `bson:"initialDuration,omitempty" json:"-"`
 	InitialStartDate time.Time          `bson:"initialStartDate,omitempty" json:"-"`
+	// version of config used by the last scheduling decision
+	ConfigVersion int64 `bson:"configVersion,omitempty" json:"configVersion,omitempty"`
+	// what actually happened, set by actualizer and by start, finish and outcome calls
+	ActualStart         time.Time `bson:"actualStart,omitempty" json:"actualStart"`
+	ActualEnd           time.Time `bson:"actualEnd,omitempty" json:"actualEnd"`
+	ProlongationCount   int32     `bson:"prolongationCount,omitempty" json:"prolongationCount"`
+	ProlongationMinutes int32     `bson:"prolongationMinutes,omitempty" json:"prolongationMinutes"`
 }
 
+// PlannedDuration returns duration requested on work creation, before prolongations and early finish.
+func (w *WorkItem) PlannedDuration() int32 {
+	if w.InitialDuration > 0 {
+		return w.InitialDuration
+	}
+	return w.DurationMinutes
+}
+
+// ActualDuration returns minutes between actual start and end, 0 if work is not finished.
+func (w *WorkItem) ActualDuration() int32 {
+	if w.ActualStart.IsZero() || w.ActualEnd.IsZero() {
+		return 0
+	}
+	return int32(w.ActualEnd.Sub(w.ActualStart).Round(time.Minute).Minutes())
+}
+
+// Prolongate sets new duration of started work and counts the prolongation.
+func (w *WorkItem) Prolongate(durationMinutes int32) {
+	if delta := durationMinutes - w.DurationMinutes; delta > 0 {
+		w.ProlongationCount++
+		w.ProlongationMinutes += delta
+	}
+	w.DurationMinutes = durationMinutes
+}
+
+// EndTime returns planned end of work, repositories store it as EndDate for range queries.
 func (w *WorkItem) EndTime() time.Time {
 	return w.StartDate.Add(time.Duration(w.DurationMinutes) * time.Minute)
 }
@@ -48,8 +85,7 @@ func (w *WorkItem) CompressFromEnd(maxCompressionRate float32, endTime time.Time
 	for endDate.Unix() >= endTime.Unix() && compressionRate > maxCompressionRate && durationMinutes >= minDuration {
 		compressionRate = compressionRate - 0.01
 		durationMinutes = int32(float32(initialDuration) * float32(compressionRate-0.01))
-		deltaTime := (w.DurationMinutes - durationMinutes) * -1
-		endDate = endDate.Add(time.Duration(deltaTime) * time.Minute)
+		endDate = w.StartDate.Add(time.Duration(durationMinutes) * time.Minute)
 	}
 	if compressionRate <= maxCompressionRate || durationMinutes < minDuration {
 		return false
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompressFromEnd(t *testing.T) {
+
+	t.Run("succees compress work to end before window end", func(t *testing.T) {
+		start := time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC)
+		windowEnd := start.Add(3 * time.Hour)
+		// end date was shifted by the difference with the initial duration on each step, so the
+		// shift grew faster than the work was compressed: 190 minutes were compressed to 182 and
+		// the work still ended after the window
+		for _, c := range []struct {
+			duration int32
+			want     int32
+		}{
+			{190, 178},
+			{300, 177},
+		} {
+			wi := &WorkItem{StartDate: start, DurationMinutes: c.duration, InitialDuration: c.duration, CompressionRate: 1}
+			if !wi.CompressFromEnd(0.5, windowEnd, 5) {
+				t.Fatalf("work of %d minutes is not compressed", c.duration)
+			}
+			if wi.EndTime().After(windowEnd) || wi.DurationMinutes < c.want-1 {
+				t.Errorf("unexpected compression of %d minutes: %d minutes, rate %v", c.duration, wi.DurationMinutes, wi.CompressionRate)
+			}
+		}
+	})
+
+	t.Run("succees keep work which can't be compressed enough", func(t *testing.T) {
+		start := time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC)
+		wi := &WorkItem{StartDate: start, DurationMinutes: 300, InitialDuration: 300, CompressionRate: 1}
+		if wi.CompressFromEnd(0.9, start.Add(3*time.Hour), 5) {
+			t.Errorf("unexpected compression to %d minutes", wi.DurationMinutes)
+		}
+		if wi.DurationMinutes != 300 || wi.CompressionRate != 1 {
+			t.Errorf("work is changed: %d minutes, rate %v", wi.DurationMinutes, wi.CompressionRate)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/scheduler/app"
+	"workScheduler/internal/settings"
+)
+
+// Check evaluates config file against the live schedule before it is deployed, it is run as
+// `scheduler --check <file> [--propose] [settings flags]` and fails if the config makes any
+// planned work illegal. Zones managed through API are merged over the file as on apply.
+func Check(ctx context.Context, path string, args []string) (err error) {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	var propose = flags.Bool("propose", false, "Generate reschedule proposals for violating works")
+	s, err := settings.Load(flags, args)
+	if err != nil {
+		return
+	}
+
+	data, err := newRepository(ctx, s)
+	if err != nil {
+		return
+	}
+	if closer, ok := data.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	// file is checked as it would be applied, i.e. with zones managed through API
+	var managed []configuration.Zone
+	if zoneData, ok := data.(repository.ZoneRepository); ok {
+		if managed, err = zoneData.ListZones(ctx); err != nil {
+			return
+		}
+	}
+	conf, err := configuration.LoadFile(path, managed)
+	if err != nil {
+		return fmt.Errorf("config %s is invalid: %w", path, err)
+	}
+
+	scheduler := app.NewScheduler(ctx, data, configuration.NewStaticConfigurator(conf))
+	impact, err := scheduler.Impact(conf, time.Now(), *propose)
+	if err != nil {
+		return
+	}
+	out, err := json.MarshalIndent(impact, "", "  ")
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(os.Stdout, string(out))
+	if len(impact.Violations) > 0 {
+		err = fmt.Errorf("config %s has %d violations in planned works", path, len(impact.Violations))
+	}
+	return
+}
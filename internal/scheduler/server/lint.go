@@ -0,0 +1,51 @@
+package server
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"workScheduler/internal/configuration"
+)
+
+var errLintUsage = errors.New("usage: scheduler config lint [--strict] <file>")
+
+// LintConfig prints all issues of config file as `file:line: severity: path: message`, it is run
+// as `scheduler config lint [--strict] <file>` and fails if the config has errors, with --strict
+// warnings fail it too.
+func LintConfig(args []string) (err error) {
+	flags := flag.NewFlagSet("config lint", flag.ContinueOnError)
+	strict := flags.Bool("strict", false, "fail on warnings too")
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if flags.NArg() != 1 {
+		return errLintUsage
+	}
+	path := flags.Arg(0)
+
+	issues, err := configuration.Lint(path)
+	if err != nil {
+		return
+	}
+	failed := 0
+	for _, issue := range issues {
+		location := path
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", path, issue.Line)
+		}
+		message := issue.Message
+		if issue.Path != "" {
+			message = fmt.Sprintf("%s: %s", issue.Path, issue.Message)
+		}
+		fmt.Fprintf(os.Stdout, "%s: %s: %s\n", location, issue.Severity, message)
+		if issue.Severity == configuration.SeverityError || *strict {
+			failed++
+		}
+	}
+	if failed > 0 {
+		err = fmt.Errorf("config %s has %d issues", path, failed)
+	}
+	return
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+
+	"workScheduler/internal/repository"
+	"workScheduler/internal/settings"
+)
+
+// Migrate applies storage migrations and exits, it is run as `scheduler migrate [settings flags]`.
+func Migrate(ctx context.Context, args []string) (err error) {
+	conf, err := settings.Load(flag.NewFlagSet("migrate", flag.ContinueOnError), args)
+	if err != nil {
+		return
+	}
+
+	data, err := newRepository(ctx, conf)
+	if err != nil {
+		return
+	}
+	if closer, ok := data.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	migrator, ok := data.(repository.Migrator)
+	if !ok {
+		log.Printf("%s storage has no migrations\n", conf.Storage)
+		return
+	}
+	if err = migrator.Migrate(ctx); err != nil {
+		return
+	}
+	if err = migrator.VerifySchema(ctx); err != nil {
+		return fmt.Errorf("schema is not valid after migrations: %w", err)
+	}
+	log.Printf("successfully migrated %s storage\n", conf.Storage)
+	return
+}
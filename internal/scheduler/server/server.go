@@ -2,22 +2,34 @@ package server
 
 import (
 	"context"
-	"flag"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"time"
 
 	"workScheduler/internal/actualizer"
 	api "workScheduler/internal/api/app"
+	"workScheduler/internal/confighistory"
 	"workScheduler/internal/configuration"
 	handlers "workScheduler/internal/handlers"
+	"workScheduler/internal/holds"
+	"workScheduler/internal/leader"
+	"workScheduler/internal/repository"
+	"workScheduler/internal/retention"
 	"workScheduler/internal/scheduler/app"
+	"workScheduler/internal/settings"
+	"workScheduler/internal/zones"
 
+	boltrepository "workScheduler/internal/repository/bolt_repository"
+	filearchive "workScheduler/internal/repository/file_archive"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
 	mongo "workScheduler/internal/repository/mongo_integrations"
+	postgres "workScheduler/internal/repository/postgres_integrations"
 
-	// inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
 	tmp "workScheduler/internal/scheduler/server/templates"
 
 	"github.com/go-openapi/runtime/middleware"
@@ -29,34 +41,106 @@ type Server struct {
 	Ctx    context.Context
 	Server *http.Server
 	Config *configuration.Configurator
+	Data   repository.ReadWriteRepository
+
+	Settings *settings.Settings
+
+	// cancel stops background jobs of the server
+	cancel  context.CancelFunc
+	elector *leader.Elector
 }
 
-func NewServer(ctx context.Context, config string) *Server {
-	c := configuration.NewConfigurator(ctx, config)
-	c.Run()
-	return &Server{
-		Ctx:    ctx,
-		Config: c,
+func NewServer(ctx context.Context, conf *settings.Settings) (*Server, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	c := configuration.NewConfigurator(ctx, conf.Config)
+	if err := c.Run(); err != nil {
+		cancel()
+		return nil, err
 	}
+	return &Server{
+		Ctx:      ctx,
+		Config:   c,
+		Settings: conf,
+		cancel:   cancel,
+	}, nil
 }
 
 func (s *Server) Run() error {
 	var err error
 	log.Println("Start web server...")
-	var port = flag.Int("port", 8080, "Port for test HTTP server")
-	flag.Parse()
 
-	data, err := mongo.NewMongoClient(s.Ctx)
+	data, err := newRepository(s.Ctx, s.Settings)
 	if err != nil {
 		return err
 	}
+	s.Data = data
+
+	if migrator, ok := data.(repository.Migrator); ok {
+		if s.Settings.Migrate {
+			if err = migrator.Migrate(s.Ctx); err != nil {
+				return err
+			}
+		}
+		if err = migrator.VerifySchema(s.Ctx); err != nil {
+			return err
+		}
+	}
+
+	archive, err := newArchive(data, s.Settings)
+	if err != nil {
+		return err
+	}
+
+	// background jobs write only while the replica is the leader, storages without leases
+	// are not shared between replicas
+	jobsData := data
+	var elector *leader.Elector
+	if leases, ok := data.(repository.LeaseRepository); ok {
+		elector = leader.NewElector(leases, "background-jobs", s.Settings.LeaseTTL)
+		s.elector = elector
+		jobsData = elector.Fence(data)
+	}
+
+	a := actualizer.NewActualizer(jobsData)
+	// works written by api and scheduler are tracked by actualizer
+	watched := a.Watch(data)
+	rt := retention.NewRetention(jobsData, archive, s.Config)
+	hr := holds.NewReleaser(jobsData, s.Config)
+
+	runJobs := func(ctx context.Context) {
+		a.Run(ctx)
+		rt.Run(ctx)
+		hr.Run(ctx)
+	}
+	if elector != nil {
+		elector.Run(s.Ctx, runJobs)
+	} else {
+		runJobs(s.Ctx)
+	}
 
-	a := actualizer.NewActualizer(data)
-	a.Run(s.Ctx)
+	// zones managed through API are merged before scheduler gets the first config
+	var syncer *zones.Syncer
+	if zoneData, ok := data.(repository.ZoneRepository); ok {
+		syncer = zones.NewSyncer(zoneData, s.Config)
+		if err := syncer.Sync(s.Ctx); err != nil {
+			log.Printf("WARNING: Find error while syncing zones, %s\n", err)
+		}
+		syncer.Run(s.Ctx)
+	}
 
-	// data := inmemoryrepository.NewInmemoryRepository()
-	scheduler := app.NewScheduler(s.Ctx, data, s.Config)
-	Server := api.NewApi(data, scheduler, s.Config)
+	// each replica follows the latest revision of config history and records configs it applies,
+	// same configs are recorded once
+	var recorder *confighistory.Recorder
+	if history, ok := data.(repository.ConfigHistoryRepository); ok {
+		recorder = confighistory.NewRecorder(history, s.Config)
+		if err := recorder.Sync(s.Ctx); err != nil {
+			log.Printf("WARNING: Find error while syncing config history, %s\n", err)
+		}
+		recorder.Run(s.Ctx)
+	}
+
+	scheduler := app.NewScheduler(s.Ctx, watched, s.Config)
+	Server := api.NewApi(watched, archive, scheduler, s.Config, recorder, syncer)
 
 	var sh http.Handler = middleware.SwaggerUI(middleware.SwaggerUIOpts{
 		SpecURL: "./static/api.yaml",
@@ -76,13 +160,13 @@ func (s *Server) Run() error {
 
 	s.Server = &http.Server{
 		Handler:     loggedRouter,
-		Addr:        fmt.Sprintf("0.0.0.0:%d", *port),
+		Addr:        fmt.Sprintf("0.0.0.0:%d", s.Settings.Port),
 		BaseContext: func(l net.Listener) context.Context { return s.Ctx },
 	}
 
 	go func() {
-		err = s.Server.ListenAndServe()
-		if err != nil {
+		// Stop shuts the server down and closes storage after that, so it is not an error
+		if err := s.Server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatal(err)
 		}
 	}()
@@ -91,6 +175,70 @@ func (s *Server) Run() error {
 
 }
 
+func newRepository(ctx context.Context, conf *settings.Settings) (repository.ReadWriteRepository, error) {
+	log.Printf("Using %s storage\n", conf.Storage)
+	switch conf.Storage {
+	case "mongo":
+		return mongo.NewMongoClient(ctx, mongo.Options{
+			URI:                  conf.MongoURI,
+			Database:             conf.MongoDatabase,
+			WorksCollection:      conf.MongoWorksCollection,
+			MigrationsCollection: conf.MongoMigrationsCollection,
+			ArchiveCollection:    conf.MongoArchiveCollection,
+			LeasesCollection:     conf.MongoLeasesCollection,
+			ConfigsCollection:    conf.MongoConfigsCollection,
+			ZonesCollection:      conf.MongoZonesCollection,
+			RetryWrites:          conf.MongoRetryWrites,
+			Timeout:              time.Duration(conf.MongoTimeoutMs) * time.Millisecond,
+		})
+	case "postgres":
+		return postgres.NewPostgresClient(ctx, postgres.Options{
+			URI:          conf.PostgresURI,
+			MaxOpenConns: conf.PostgresMaxOpenConns,
+		})
+	case "memory":
+		return inmemoryrepository.NewInmemoryRepositoryWithSnapshots(ctx, conf.MemorySnapshotPath,
+			time.Duration(conf.MemorySnapshotIntervalSec)*time.Second)
+	case "embedded":
+		return boltrepository.NewBoltRepository(ctx, conf.EmbeddedPath)
+	default:
+		return nil, fmt.Errorf("unknown storage %q, expected one of: mongo, postgres, memory, embedded", conf.Storage)
+	}
+}
+
+func newArchive(data repository.ReadWriteRepository, conf *settings.Settings) (repository.Archive, error) {
+	storageArchive, supported := data.(repository.Archive)
+	switch conf.Archive {
+	case "":
+		if supported {
+			return storageArchive, nil
+		}
+		return filearchive.NewFileArchive(conf.ArchivePath)
+	case "storage":
+		if !supported {
+			return nil, fmt.Errorf("storage archive is not supported by the storage, use files archive")
+		}
+		return storageArchive, nil
+	case "files":
+		return filearchive.NewFileArchive(conf.ArchivePath)
+	default:
+		return nil, fmt.Errorf("unknown archive %q, expected one of: storage, files", conf.Archive)
+	}
+}
+
+// Stop shuts down HTTP server and background jobs, the leader lease is released right away and
+// storage is closed last, so the memory storage saves its final snapshot before Stop returns.
 func (s *Server) Stop() {
-	s.Server.Shutdown(s.Ctx)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
+	s.Server.Shutdown(shutdownCtx)
+	s.cancel()
+	if s.elector != nil {
+		s.elector.Wait()
+	}
+	if closer, ok := s.Data.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Printf("WARNING: unable to close storage: %s\n", err)
+		}
+	}
 }
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"workScheduler/internal/repository"
+	inmemoryrepository "workScheduler/internal/repository/inmemory_repository"
+	"workScheduler/internal/scheduler/models"
+	"workScheduler/internal/settings"
+)
+
+func runTestServer(t *testing.T) (s *Server, snapshot string) {
+	dir := t.TempDir()
+	snapshot = filepath.Join(dir, "snapshot.bson")
+	conf := settings.Defaults()
+	conf.Config = "../../../config.yml"
+	conf.Port = 0
+	conf.Storage = "memory"
+	conf.MemorySnapshotPath = snapshot
+	conf.ArchivePath = filepath.Join(dir, "archive")
+
+	s, err := NewServer(context.Background(), conf)
+	if err != nil {
+		t.Fatalf("unable to create server: %v", err)
+	}
+	if err := s.Run(); err != nil {
+		t.Fatalf("unable to run server: %v", err)
+	}
+	return
+}
+
+func TestStop(t *testing.T) {
+
+	t.Run("succees save snapshot of memory storage on stop", func(t *testing.T) {
+		s, snapshot := runTestServer(t)
+		if _, err := s.Data.Add(context.Background(), &models.WorkItem{WorkId: "work", Zones: []string{"zone1"}, StartDate: time.Now(), DurationMinutes: 60, Status: models.StatusPlanned}); err != nil {
+			t.Fatalf("unable to add work: %v", err)
+		}
+
+		s.Stop()
+
+		restored := inmemoryrepository.NewInmemoryRepository()
+		if err := restored.Restore(snapshot); err != nil {
+			t.Fatalf("unable to restore snapshot: %v", err)
+		}
+		if works, err := restored.GetById(context.Background(), "work"); err != nil || len(works) != 1 {
+			t.Errorf("unexpected works in snapshot: %v %v", works, err)
+		}
+	})
+
+	t.Run("succees release leader lease on stop", func(t *testing.T) {
+		s, _ := runTestServer(t)
+		leases := s.Data.(repository.LeaseRepository)
+		// the replica is elected right after start
+		deadline := time.Now().Add(5 * time.Second)
+		for s.elector.Token() == 0 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if s.elector.Token() == 0 {
+			t.Fatal("replica is not elected")
+		}
+
+		s.Stop()
+
+		if _, acquired, err := leases.AcquireLease(context.Background(), "background-jobs", "other", time.Minute); err != nil || !acquired {
+			t.Errorf("lease is not released on stop: %v %v", acquired, err)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"workScheduler/internal/settings"
+)
+
+// DumpSettings prints effective settings as `key = value (source)` with secrets redacted, it is
+// run as `scheduler settings dump [--json] [settings flags]` with the same settings sources as
+// the server.
+func DumpSettings(args []string) (err error) {
+	flags := flag.NewFlagSet("settings dump", flag.ContinueOnError)
+	var asJSON = flags.Bool("json", false, "Print settings as JSON")
+	s, err := settings.Load(flags, args)
+	if err != nil {
+		return
+	}
+	dump := s.Dump()
+	if *asJSON {
+		out, err := json.MarshalIndent(dump, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, string(out))
+		return nil
+	}
+	for _, setting := range dump {
+		fmt.Fprintf(os.Stdout, "%s = %s (%s)\n", setting.Key, setting.Value, setting.Source)
+	}
+	return
+}
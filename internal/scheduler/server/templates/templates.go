@@ -73,7 +73,7 @@ func (t *Template) Generate(w http.ResponseWriter, r *http.Request) {
 	endDate := ts.AddDate(0, 0, 2)
 	tmpls := map[string][]TemplateData{}
 
-	works, err := t.Data.List(r.Context(), startDate, endDate, []string{}, []string{})
+	works, err := t.Data.List(r.Context(), startDate, endDate, []string{}, []string{}, repository.ListModeOverlaps)
 	if err != nil {
 		log.Printf("ERROR: Can't generate template for '/' request, %s", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -90,10 +90,21 @@ func (t *Template) Generate(w http.ResponseWriter, r *http.Request) {
 				day := startDate.Format(time.DateOnly)
 				hour := startDate.Hour()
 				minute := startDate.Minute() / 2
+				if _, ok := tmp.Date[day]; !ok {
+					// work started or ends outside of displayed days
+					startDate = startDate.Add(2 * time.Minute)
+					continue
+				}
 				if work.Status == "canceled" {
 					tmp.Date[day][hour].Minutes[minute].Value = "#DCDCDC"
 				} else if work.Status == "in_progress" {
 					tmp.Date[day][hour].Minutes[minute].Value = "#7B68EE"
+				} else if work.Status == "overdue" {
+					tmp.Date[day][hour].Minutes[minute].Value = "#FF6347"
+				} else if work.Status == "failed" || work.Status == "rolled_back" || work.Status == "aborted" {
+					tmp.Date[day][hour].Minutes[minute].Value = "#B22222"
+				} else if work.Status == "held" {
+					tmp.Date[day][hour].Minutes[minute].Value = "#FFD700"
 				} else if work.Status == "planned" {
 					tmp.Date[day][hour].Minutes[minute].Value = "#00FA9A"
 				} else {
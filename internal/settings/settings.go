@@ -0,0 +1,289 @@
+package settings
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"workScheduler/internal/leader"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Settings are process settings resolved from layers, each next layer overrides the previous one:
+// defaults, settings files in the given order (e.g. base and environment-specific), environment
+// variables and command line flags. Each value is set by the key of its `setting` tag, which is a
+// dotted path in settings files and, with dots and underscores replaced by dashes, a flag name.
+type Settings struct {
+	Config   string        `setting:"config" env:"SCHEDULER_CONFIG" usage:"Scheduling rules file, it is reloaded on change"`
+	Port     int           `setting:"port" env:"SCHEDULER_PORT" usage:"Port for HTTP server"`
+	Storage  string        `setting:"storage" env:"SCHEDULER_STORAGE" usage:"Storage backend for works: mongo|postgres|memory|embedded"`
+	Migrate  bool          `setting:"migrate" env:"SCHEDULER_MIGRATE" usage:"Apply storage migrations on startup, otherwise only check that schema is up to date"`
+	LeaseTTL time.Duration `setting:"lease_ttl" env:"SCHEDULER_LEASE_TTL" usage:"TTL of the leader lease, only the leader replica runs background jobs"`
+	Archive  string        `setting:"archive" env:"SCHEDULER_ARCHIVE" usage:"Archive for works removed by retention: storage|files, by default storage archive is used if supported, otherwise files"`
+
+	ArchivePath string `setting:"archive_path" env:"ARCHIVE_PATH" usage:"Directory of files archive"`
+
+	MongoURI                  string `setting:"mongo.uri" env:"MONGO_URI" secret:"true" usage:"Mongo connection string"`
+	MongoDatabase             string `setting:"mongo.database" env:"MONGO_DATABASE" usage:"Mongo database"`
+	MongoWorksCollection      string `setting:"mongo.works_collection" env:"MONGO_WORKS_COLLECTION" usage:"Mongo collection of works"`
+	MongoMigrationsCollection string `setting:"mongo.migrations_collection" env:"MONGO_MIGRATIONS_COLLECTION" usage:"Mongo collection of applied migrations"`
+	MongoArchiveCollection    string `setting:"mongo.archive_collection" env:"MONGO_ARCHIVE_COLLECTION" usage:"Mongo collection of archived works"`
+	MongoLeasesCollection     string `setting:"mongo.leases_collection" env:"MONGO_LEASES_COLLECTION" usage:"Mongo collection of leader leases"`
+	MongoConfigsCollection    string `setting:"mongo.configs_collection" env:"MONGO_CONFIGS_COLLECTION" usage:"Mongo collection of config history"`
+	MongoZonesCollection      string `setting:"mongo.zones_collection" env:"MONGO_ZONES_COLLECTION" usage:"Mongo collection of zones managed through API"`
+	MongoRetryWrites          bool   `setting:"mongo.retry_writes" env:"MONGO_RETRY_WRITES" usage:"Retry mongo writes"`
+	MongoTimeoutMs            int    `setting:"mongo.timeout_ms" env:"MONGO_TIMEOUT_MS" usage:"Mongo operations timeout in milliseconds, 0 for driver default"`
+
+	PostgresURI          string `setting:"postgres.uri" env:"POSTGRES_URI" secret:"true" usage:"Postgres connection string"`
+	PostgresMaxOpenConns int    `setting:"postgres.max_open_conns" env:"POSTGRES_MAX_OPEN_CONNS" usage:"Max open postgres connections, 0 for unlimited"`
+
+	MemorySnapshotPath        string `setting:"memory.snapshot_path" env:"INMEMORY_SNAPSHOT_PATH" usage:"Snapshot file of memory storage, snapshots are disabled if empty"`
+	MemorySnapshotIntervalSec int    `setting:"memory.snapshot_interval_sec" env:"INMEMORY_SNAPSHOT_INTERVAL_SEC" usage:"Interval of memory storage snapshots in seconds"`
+
+	EmbeddedPath string `setting:"embedded.path" env:"BOLT_PATH" usage:"File of embedded storage"`
+
+	// Files are settings files the settings were read from
+	Files []string
+	// Sources maps each setting key to the layer its value came from
+	Sources map[string]string
+}
+
+// FilesEnv lists settings files separated by commas, --settings flags replace it.
+const FilesEnv = "SCHEDULER_SETTINGS"
+
+func Defaults() *Settings {
+	return &Settings{
+		Config:                    "./config.yml",
+		Port:                      8080,
+		Storage:                   "mongo",
+		Migrate:                   true,
+		LeaseTTL:                  leader.DefaultTTL,
+		ArchivePath:               "archive",
+		MongoMigrationsCollection: "migrations",
+		MongoArchiveCollection:    "works_archive",
+		MongoLeasesCollection:     "leases",
+		MongoConfigsCollection:    "config_history",
+		MongoZonesCollection:      "zones",
+		MemorySnapshotIntervalSec: 60,
+		EmbeddedPath:              "scheduler.db",
+	}
+}
+
+type field struct {
+	key    string
+	env    string
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+func (s *Settings) fields() (fields []field) {
+	v := reflect.ValueOf(s).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get("setting")
+		if key == "" {
+			continue
+		}
+		fields = append(fields, field{
+			key:    key,
+			env:    t.Field(i).Tag.Get("env"),
+			usage:  t.Field(i).Tag.Get("usage"),
+			secret: t.Field(i).Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+	return
+}
+
+func flagName(key string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(key)
+}
+
+func (f field) set(value string) (err error) {
+	switch f.value.Interface().(type) {
+	case string:
+		f.value.SetString(value)
+	case int:
+		var i int
+		if i, err = strconv.Atoi(value); err == nil {
+			f.value.SetInt(int64(i))
+		}
+	case bool:
+		var b bool
+		if b, err = strconv.ParseBool(value); err == nil {
+			f.value.SetBool(b)
+		}
+	case time.Duration:
+		var d time.Duration
+		if d, err = time.ParseDuration(value); err == nil {
+			f.value.SetInt(int64(d))
+		}
+	default:
+		err = fmt.Errorf("unsupported type %s", f.value.Type())
+	}
+	if err != nil {
+		err = fmt.Errorf("invalid value %q of %s: %w", value, f.key, err)
+	}
+	return
+}
+
+func (f field) String() string {
+	value := fmt.Sprint(f.value.Interface())
+	if f.secret && value != "" {
+		return redact(value)
+	}
+	return value
+}
+
+// redact hides password of connection string.
+func redact(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "xxxxx")
+	}
+	return u.String()
+}
+
+type filesFlag []string
+
+func (f *filesFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *filesFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// Load registers settings flags on flags, parses args and resolves settings.
+func Load(flags *flag.FlagSet, args []string) (s *Settings, err error) {
+	s = Defaults()
+	s.Sources = map[string]string{}
+	fields := s.fields()
+
+	var files filesFlag
+	flags.Var(&files, "settings", fmt.Sprintf("Settings file, may be repeated, later files override earlier ones (default from %s)", FilesEnv))
+	for _, f := range fields {
+		if _, ok := f.value.Interface().(bool); ok {
+			flags.Bool(flagName(f.key), f.value.Bool(), f.usage)
+		} else {
+			flags.String(flagName(f.key), f.String(), f.usage)
+		}
+		s.Sources[f.key] = "default"
+	}
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+
+	s.Files = files
+	if len(s.Files) == 0 {
+		if env := os.Getenv(FilesEnv); env != "" {
+			s.Files = strings.Split(env, ",")
+		}
+	}
+	for _, path := range s.Files {
+		if err = s.loadFile(fields, path); err != nil {
+			return
+		}
+	}
+
+	for _, f := range fields {
+		if value, ok := os.LookupEnv(f.env); ok && f.env != "" {
+			if err = f.set(value); err != nil {
+				return
+			}
+			s.Sources[f.key] = "env " + f.env
+		}
+	}
+
+	byFlag := map[string]field{}
+	for _, f := range fields {
+		byFlag[flagName(f.key)] = f
+	}
+	flags.Visit(func(fl *flag.Flag) {
+		f, ok := byFlag[fl.Name]
+		if !ok || err != nil {
+			return
+		}
+		if err = f.set(fl.Value.String()); err == nil {
+			s.Sources[f.key] = "flag --" + fl.Name
+		}
+	})
+	return
+}
+
+func (s *Settings) loadFile(fields []field, path string) (err error) {
+	document, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var root yaml.Node
+	if err = yaml.Unmarshal(document, &root); err != nil {
+		return fmt.Errorf("settings file %s is invalid: %w", path, err)
+	}
+	var keys []string
+	values := map[string]*yaml.Node{}
+	if len(root.Content) > 0 {
+		flatten(root.Content[0], "", &keys, values)
+	}
+	byKey := map[string]field{}
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+	for _, key := range keys {
+		node := values[key]
+		f, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("settings file %s line %d: unknown setting %s", path, node.Line, key)
+		}
+		if node.Kind != yaml.ScalarNode {
+			return fmt.Errorf("settings file %s line %d: %s must be a scalar value", path, node.Line, key)
+		}
+		if err = f.set(node.Value); err != nil {
+			return fmt.Errorf("settings file %s line %d: %w", path, node.Line, err)
+		}
+		s.Sources[key] = "file " + path
+	}
+	return
+}
+
+// flatten collects scalar values of nested mappings by dotted keys in document order.
+func flatten(node *yaml.Node, prefix string, keys *[]string, values map[string]*yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		*keys = append(*keys, prefix)
+		values[prefix] = node
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		flatten(node.Content[i+1], key, keys, values)
+	}
+}
+
+// Setting is a resolved setting value with its source, secrets are redacted.
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// Dump lists all settings in declaration order.
+func (s *Settings) Dump() (dump []Setting) {
+	for _, f := range s.fields() {
+		dump = append(dump, Setting{Key: f.key, Value: f.String(), Source: s.Sources[f.key]})
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package settings
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+
+	t.Run("succees override defaults by files then env then flags", func(t *testing.T) {
+		base := writeFile(t, "base.yml", "port: 9090\nstorage: postgres\npostgres:\n  uri: postgres://user:secret@db/scheduler\n  max_open_conns: 10\n")
+		prod := writeFile(t, "prod.yml", "port: 9091\n")
+		t.Setenv(FilesEnv, base+","+prod)
+		t.Setenv("POSTGRES_MAX_OPEN_CONNS", "20")
+
+		s, err := Load(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--lease-ttl", "30s"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Port != 9091 || s.Storage != "postgres" || s.PostgresMaxOpenConns != 20 || s.LeaseTTL != 30*time.Second || s.Config != "./config.yml" {
+			t.Errorf("unexpected settings: %+v", s)
+		}
+		sources := map[string]string{
+			"port":                    "file " + prod,
+			"storage":                 "file " + base,
+			"postgres.max_open_conns": "env POSTGRES_MAX_OPEN_CONNS",
+			"lease_ttl":               "flag --lease-ttl",
+			"config":                  "default",
+		}
+		for key, source := range sources {
+			if s.Sources[key] != source {
+				t.Errorf("wrong source of %s: want %s, got %s", key, source, s.Sources[key])
+			}
+		}
+		for _, setting := range s.Dump() {
+			if setting.Key == "postgres.uri" && strings.Contains(setting.Value, "secret") {
+				t.Errorf("secret is not redacted: %s", setting.Value)
+			}
+		}
+	})
+
+	t.Run("succees settings flag replaces files from env", func(t *testing.T) {
+		t.Setenv(FilesEnv, writeFile(t, "env.yml", "port: 9090\n"))
+		file := writeFile(t, "flag.yml", "storage: memory\n")
+
+		s, err := Load(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--settings", file})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Port != 8080 || s.Storage != "memory" {
+			t.Errorf("unexpected settings: %+v", s)
+		}
+	})
+
+	t.Run("error on unknown setting in file", func(t *testing.T) {
+		file := writeFile(t, "bad.yml", "port: 9090\nmongo:\n  url: mongodb://db\n")
+
+		_, err := Load(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--settings", file})
+		if err == nil || !strings.Contains(err.Error(), "line 3: unknown setting mongo.url") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("error on invalid env value", func(t *testing.T) {
+		t.Setenv("SCHEDULER_PORT", "http")
+
+		if _, err := Load(flag.NewFlagSet("test", flag.ContinueOnError), nil); err == nil {
+			t.Errorf("expected error for invalid port")
+		}
+	})
+}
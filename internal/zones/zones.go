@@ -0,0 +1,128 @@
+package zones
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+	"workScheduler/internal/configuration"
+	"workScheduler/internal/repository"
+)
+
+const interval = time.Minute
+
+// Syncer merges zones managed through API over config file, zones saved by other replicas are
+// loaded periodically.
+type Syncer struct {
+	Repository repository.ZoneRepository
+	Config     *configuration.Configurator
+}
+
+// State is zone of the effective config, Managed zones are saved through API.
+type State struct {
+	configuration.Zone
+	Managed bool `json:"managed"`
+}
+
+func NewSyncer(repo repository.ZoneRepository, config *configuration.Configurator) *Syncer {
+	return &Syncer{
+		Repository: repo,
+		Config:     config,
+	}
+}
+
+func (s *Syncer) Run(ctx context.Context) {
+	go s.process(ctx)
+}
+
+func (s *Syncer) process(ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Sync(ctx); err != nil {
+				log.Printf("WARNING: Find error while syncing zones, %s\n", err)
+			}
+		}
+	}
+}
+
+// Sync applies saved zones to config.
+func (s *Syncer) Sync(ctx context.Context) (err error) {
+	zones, err := s.Repository.ListZones(ctx)
+	if err != nil {
+		return
+	}
+	_, err = s.Config.SetZones(zones)
+	return
+}
+
+// Save checks that effective config with the zone is valid, then saves and applies it.
+func (s *Syncer) Save(ctx context.Context, zone configuration.Zone) (conf *configuration.Config, err error) {
+	zones, err := s.Repository.ListZones(ctx)
+	if err != nil {
+		return
+	}
+	replaced := false
+	for i := range zones {
+		if zones[i].ZoneId == zone.ZoneId {
+			zones[i] = zone
+			replaced = true
+		}
+	}
+	if !replaced {
+		zones = append(zones, zone)
+		sort.Slice(zones, func(i, j int) bool {
+			return zones[i].ZoneId < zones[j].ZoneId
+		})
+	}
+	if err = s.Config.ValidateZones(zones); err != nil {
+		return
+	}
+	if err = s.Repository.SaveZone(ctx, zone); err != nil {
+		return
+	}
+	conf, err = s.Config.SetZones(zones)
+	if err == nil {
+		log.Printf("successfully saved zone %s\n", zone.ZoneId)
+	}
+	return
+}
+
+// Effective lists zones of config ordered by id, decommissioned managed zones are listed too.
+// Black list is resolved at the time of the call, so scheduled entries are applied and lifted
+// without config reload.
+func Effective(conf *configuration.Config, managed []configuration.Zone) (states []State) {
+	byId := map[string]*State{}
+	for zoneId, windows := range conf.WhiteList {
+		byId[zoneId] = &State{Zone: configuration.Zone{ZoneId: zoneId, Windows: windows}}
+	}
+	for _, zoneId := range conf.At(time.Now()).BlackList {
+		byId[zoneId] = &State{Zone: configuration.Zone{ZoneId: zoneId, BlackListed: true}}
+	}
+	for zoneId, pause := range conf.PausesMinutes {
+		if state, ok := byId[zoneId]; ok {
+			state.PauseMinutes = pause
+		}
+	}
+	for _, zone := range managed {
+		if state, ok := byId[zone.ZoneId]; ok {
+			state.Managed = true
+		} else if zone.Decommissioned {
+			byId[zone.ZoneId] = &State{Zone: zone, Managed: true}
+		}
+	}
+
+	states = []State{}
+	for _, state := range byId {
+		states = append(states, *state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].ZoneId < states[j].ZoneId
+	})
+	return
+}
@@ -0,0 +1,55 @@
+package zones
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"workScheduler/internal/configuration"
+)
+
+func TestEffective(t *testing.T) {
+
+	t.Run("succees lift black list entry without config reload", func(t *testing.T) {
+		until := time.Now().Add(2 * time.Second).UTC().Format(time.RFC3339)
+		document := []byte(fmt.Sprintf(`white_list:
+  zone1:
+    - start_hour: 6
+      end_hour: 18
+  zone3:
+    - start_hour: 6
+      end_hour: 18
+  zone4:
+    - start_hour: 6
+      end_hour: 18
+black_list:
+  - zone: zone2
+    effective_until: %s
+min_work_duration_minutes:
+  automatic: 5
+  manual: 30
+max_work_duration_minutes:
+  automatic: 360
+  manual: 360
+max_deadline_days: 28
+`, until))
+		conf, err := configuration.NewStaticConfigurator(&configuration.Config{}).Apply(document)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		blackListed := func() bool {
+			for _, state := range Effective(conf, nil) {
+				if state.ZoneId == "zone2" {
+					return state.BlackListed
+				}
+			}
+			return false
+		}
+		if !blackListed() {
+			t.Fatal("zone is not black listed before effective_until")
+		}
+		time.Sleep(time.Until(conf.BlackListEntries[0].Until) + 10*time.Millisecond)
+		if blackListed() {
+			t.Error("zone is black listed after effective_until")
+		}
+	})
+}
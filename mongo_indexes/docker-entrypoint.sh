@@ -1,3 +0,0 @@
-#!/bin/bash
-echo "MONGO_SCRIPT = $MONGO_SCRIPT"
-mongosh --host $MONGO_SCRIPTS_HOST --port $MONGO_SCRIPTS_PORT $MONGO_SCRIPT
\ No newline at end of file